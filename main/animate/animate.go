@@ -0,0 +1,150 @@
+// Package animate makes animated GIFs of pictures changing with
+// time, like the pictures of the gallery package with a parameter
+// moving from frame to frame. Frames are rendered in parallel by a
+// pool of Options.Workers goroutines, runtime.NumCPU() by default,
+// and share a palette quantized from them all.
+package animate
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"math"
+	"strings"
+
+	"golearning/gallery"
+	"golearning/parallel"
+)
+
+// An Animation returns its picture at the time t, from 0 at the
+// first frame to 1 excluded: the frame i of n is at t = i/n, so
+// that an animation going back at 1 to its picture at 0 loops.
+type Animation func(t float64) image.Image
+
+// Options configure GIF. The zero value, or nil, uses the defaults.
+type Options struct {
+	Frames int // number of frames, 24 if zero
+	Delay  int // delay between frames in hundredths of a second, 8 if zero
+
+	// Palette is the palette of the frames. If nil, a palette of
+	// Colors colors, 256 if zero, is quantized from the frames.
+	Palette color.Palette
+	Colors  int
+	// Dither diffuses the error of the colors missing from the
+	// palette, with the Floyd-Steinberg algorithm.
+	Dither bool
+
+	Workers int // goroutines rendering the frames, runtime.NumCPU() if zero
+}
+
+func (o *Options) withDefaults() Options {
+	var opts Options
+	if o != nil {
+		opts = *o
+	}
+	if opts.Frames <= 0 {
+		opts.Frames = 24
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = 8
+	}
+	if opts.Colors <= 0 || opts.Colors > 256 {
+		opts.Colors = 256
+	}
+	return opts
+}
+
+// GIF renders the frames of a, and returns them as an animated GIF
+// looping forever. The frames are the same whatever the number of
+// workers.
+func GIF(a Animation, opts *Options) *gif.GIF {
+	o := opts.withDefaults()
+	frames := make([]image.Image, o.Frames)
+	parallel.For(o.Frames, o.Workers, func(i int) {
+		m := a(float64(i) / float64(o.Frames))
+		rgba := image.NewRGBA(m.Bounds())
+		draw.Draw(rgba, rgba.Bounds(), m, m.Bounds().Min, draw.Src)
+		frames[i] = rgba
+	})
+
+	palette := o.Palette
+	if palette == nil {
+		palette = Quantize(frames, o.Colors)
+	}
+	var drawer draw.Drawer = draw.Src
+	if o.Dither {
+		drawer = draw.FloydSteinberg
+	}
+	g := &gif.GIF{
+		Image: make([]*image.Paletted, o.Frames),
+		Delay: make([]int, o.Frames),
+	}
+	parallel.For(o.Frames, o.Workers, func(i int) {
+		p := image.NewPaletted(frames[i].Bounds(), palette)
+		drawer.Draw(p, p.Bounds(), frames[i], frames[i].Bounds().Min)
+		g.Image[i] = p
+		g.Delay[i] = o.Delay
+	})
+	return g
+}
+
+// Gradient is a linear gradient, its levels growing in the
+// direction of Angle, in radians, across the canvas.
+type Gradient struct {
+	gallery.Canvas
+	Angle float64
+}
+
+func (m Gradient) At(x, y int) color.Color {
+	dx, dy := math.Cos(m.Angle), math.Sin(m.Angle)
+	w, h := float64(m.Width), float64(m.Height)
+	// The projections of the corners are within ±half.
+	half := (math.Abs(dx)*w + math.Abs(dy)*h) / 2
+	p := (float64(x)-w/2)*dx + (float64(y)-h/2)*dy
+	return m.Color(uint8(math.Round(255 * (p + half) / (2 * half))))
+}
+
+// zoomCenter is a point on the edge of the Mandelbrot set, in the
+// "seahorse valley", where zooming keeps showing details.
+const zoomCenter = -0.743643887037151 + 0.13182590420533i
+
+// Animations are the animations by name, on a canvas with a colormap.
+var Animations = []struct {
+	Name string
+	New  func(c gallery.Canvas) Animation
+}{
+	{"gradient", func(c gallery.Canvas) Animation {
+		return func(t float64) image.Image { return Gradient{c, 2 * math.Pi * t} }
+	}},
+	{"zoom", func(c gallery.Canvas) Animation {
+		// From the whole set to a thousandth of it.
+		return func(t float64) image.Image {
+			width := 3 * math.Pow(1e-3, t)
+			center := -0.5 + (zoomCenter+0.5)*complex(math.Min(1, 4*t), 0)
+			return gallery.Mandelbrot{Canvas: c, View: gallery.View{Center: center, Width: width}, MaxIter: 512}
+		}
+	}},
+	{"julia", func(c gallery.Canvas) Animation {
+		// C goes around a circle through connected Julia sets.
+		return func(t float64) image.Image {
+			return gallery.Julia{Canvas: c, C: complex(0.7885, 0) * complex(math.Cos(2*math.Pi*t), math.Sin(2*math.Pi*t))}
+		}
+	}},
+	{"plasma", func(c gallery.Canvas) Animation {
+		// The waves of the plasma all have periods dividing 24π,
+		// so the plasma loops.
+		return func(t float64) image.Image { return gallery.Plasma{Canvas: c, Phase: 24 * math.Pi * t} }
+	}},
+}
+
+// Lookup returns the animation called name, in any case,
+// on the canvas c.
+func Lookup(name string, c gallery.Canvas) (Animation, bool) {
+	for _, a := range Animations {
+		if strings.EqualFold(a.Name, name) {
+			return a.New(c), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,120 @@
+package animate
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"math"
+	"reflect"
+	"testing"
+
+	"golearning/gallery"
+	"golearning/render"
+)
+
+var canvas = gallery.Canvas{Width: 32, Height: 24, Colormap: render.Heat}
+
+func TestGIF(t *testing.T) {
+	a, _ := Lookup("gradient", canvas)
+	g := GIF(a, &Options{Frames: 6, Delay: 5, Colors: 16})
+	if len(g.Image) != 6 || !reflect.DeepEqual(g.Delay, []int{5, 5, 5, 5, 5, 5}) {
+		t.Fatalf("%d frames, delays %v, want 6 frames of 5", len(g.Image), g.Delay)
+	}
+	for i, m := range g.Image {
+		if m.Bounds() != image.Rect(0, 0, 32, 24) || len(m.Palette) > 16 {
+			t.Errorf("frame %d: %v, %d colors", i, m.Bounds(), len(m.Palette))
+		}
+	}
+	// The frames are different.
+	if bytes.Equal(g.Image[0].Pix, g.Image[1].Pix) {
+		t.Error("frames 0 and 1 are the same")
+	}
+
+	var b bytes.Buffer
+	if err := gif.EncodeAll(&b, g); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := gif.DecodeAll(&b)
+	if err != nil || len(decoded.Image) != 6 {
+		t.Errorf("decoded %v, %v", decoded, err)
+	}
+}
+
+func TestGIFDefaults(t *testing.T) {
+	a, _ := Lookup("plasma", canvas)
+	g := GIF(a, nil)
+	if len(g.Image) != 24 || g.Delay[0] != 8 {
+		t.Errorf("%d frames of %d, want 24 frames of 8", len(g.Image), g.Delay[0])
+	}
+}
+
+// TestParallel checks that the frames are the same whatever
+// the number of goroutines rendering them.
+func TestParallel(t *testing.T) {
+	for _, anim := range Animations {
+		a := anim.New(canvas)
+		serial := GIF(a, &Options{Frames: 8, Workers: 1, Dither: true})
+		parallel := GIF(a, &Options{Frames: 8, Workers: 8, Dither: true})
+		if !reflect.DeepEqual(serial, parallel) {
+			t.Errorf("%s: the frames rendered in parallel differ", anim.Name)
+		}
+	}
+}
+
+func TestPalette(t *testing.T) {
+	a, _ := Lookup("julia", canvas)
+	g := GIF(a, &Options{Frames: 2, Palette: palette.WebSafe})
+	if !reflect.DeepEqual(g.Image[0].Palette, color.Palette(palette.WebSafe)) {
+		t.Error("the frames do not use the given palette")
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	// An image of 3 colors gets a palette of these 3 colors.
+	m := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	colors := []color.RGBA{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}}
+	for i := range m.Pix[:len(m.Pix)/4] {
+		m.SetRGBA(i%10, i/10, colors[i%3])
+	}
+	p := Quantize([]image.Image{m}, 16)
+	if len(p) != 3 {
+		t.Fatalf("palette %v, want the 3 colors", p)
+	}
+	for _, c := range colors {
+		if p.Convert(c) != c {
+			t.Errorf("%v is not in the palette %v", c, p)
+		}
+	}
+
+	// A gradient of 256 levels quantized to n colors is off
+	// by at most 256/n.
+	gray := render.Recolor(Gradient{gallery.Canvas{Width: 256, Height: 4, Colormap: render.Gray}, 0}, render.Gray)
+	for _, n := range []int{2, 16, 64} {
+		p := Quantize([]image.Image{gray}, n)
+		if len(p) != n {
+			t.Errorf("%d colors, want %d", len(p), n)
+		}
+		for x := 0; x < 256; x++ {
+			c := gray.RGBAAt(x, 0)
+			q := p.Convert(c).(color.RGBA)
+			if d := math.Abs(float64(q.R) - float64(c.R)); d > 256/float64(n) {
+				t.Errorf("%d colors: %v quantized to %v", n, c, q)
+				break
+			}
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	gray := gallery.Canvas{Width: 100, Height: 100, Colormap: render.Gray}
+	left := Gradient{gray, 0}
+	if left.At(0, 50) != render.Gray(0) || left.At(99, 50) != render.Gray(252) {
+		t.Errorf("gradient at 0: from %v to %v", left.At(0, 50), left.At(99, 50))
+	}
+	down := Gradient{gray, math.Pi / 2}
+	if down.At(50, 0) != render.Gray(0) || down.At(50, 99) != render.Gray(252) {
+		t.Errorf("gradient at π/2: from %v to %v", down.At(50, 0), down.At(50, 99))
+	}
+}
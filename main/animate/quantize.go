@@ -0,0 +1,119 @@
+package animate
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// maxSamples bounds the number of pixels Quantize looks at.
+const maxSamples = 1 << 16
+
+// Quantize returns a palette of at most n colors close to the colors
+// of the images, by the median cut algorithm: starting from a box
+// holding all the colors, it splits the box with the widest range
+// of a component at the median of that component, until there are
+// n boxes. The palette has the average color of each box.
+func Quantize(images []image.Image, n int) color.Palette {
+	var total int
+	for _, m := range images {
+		total += m.Bounds().Dx() * m.Bounds().Dy()
+	}
+	step := max(1, total/maxSamples)
+	var pixels []color.RGBA
+	i := 0
+	for _, m := range images {
+		b := m.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if i%step == 0 {
+					pixels = append(pixels, color.RGBAModel.Convert(m.At(x, y)).(color.RGBA))
+				}
+				i++
+			}
+		}
+	}
+	if len(pixels) == 0 {
+		return color.Palette{color.Black}
+	}
+
+	boxes := []box{newBox(pixels)}
+	for len(boxes) < n {
+		// Split the box with the widest range.
+		widest := 0
+		for i, b := range boxes {
+			if b.width > boxes[widest].width {
+				widest = i
+			}
+		}
+		if boxes[widest].width == 0 {
+			break // every box holds a single color
+		}
+		low, high := boxes[widest].split()
+		boxes[widest] = low
+		boxes = append(boxes, high)
+	}
+	palette := make(color.Palette, len(boxes))
+	for i, b := range boxes {
+		palette[i] = b.average()
+	}
+	return palette
+}
+
+// A box is a set of colors, with the component with the widest
+// range of values among them.
+type box struct {
+	pixels    []color.RGBA
+	component int // 0 for red, 1 for green, 2 for blue, 3 for alpha
+	width     uint8
+}
+
+func component(c color.RGBA, i int) uint8 {
+	return [4]uint8{c.R, c.G, c.B, c.A}[i]
+}
+
+func newBox(pixels []color.RGBA) box {
+	b := box{pixels: pixels}
+	for i := 0; i < 4; i++ {
+		lo, hi := uint8(255), uint8(0)
+		for _, p := range pixels {
+			lo, hi = min(lo, component(p, i)), max(hi, component(p, i))
+		}
+		if hi > lo && hi-lo > b.width {
+			b.component, b.width = i, hi-lo
+		}
+	}
+	return b
+}
+
+// split splits b at the median of its widest component.
+func (b box) split() (box, box) {
+	sort.Slice(b.pixels, func(i, j int) bool {
+		return component(b.pixels[i], b.component) < component(b.pixels[j], b.component)
+	})
+	// Cut between two different values, so that
+	// no color ends up in both boxes.
+	mid := len(b.pixels) / 2
+	v := component(b.pixels[mid], b.component)
+	for mid > 0 && component(b.pixels[mid-1], b.component) == v {
+		mid--
+	}
+	if mid == 0 {
+		for mid < len(b.pixels) && component(b.pixels[mid], b.component) == v {
+			mid++
+		}
+	}
+	return newBox(b.pixels[:mid]), newBox(b.pixels[mid:])
+}
+
+func (b box) average() color.RGBA {
+	var r, g, bl, a int
+	for _, p := range b.pixels {
+		r += int(p.R)
+		g += int(p.G)
+		bl += int(p.B)
+		a += int(p.A)
+	}
+	n := len(b.pixels)
+	return color.RGBA{uint8(r / n), uint8(g / n), uint8(bl / n), uint8(a / n)}
+}
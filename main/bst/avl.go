@@ -0,0 +1,49 @@
+package bst
+
+import "cmp"
+
+func height[K cmp.Ordered](n *node[K]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func updateHeight[K cmp.Ordered](n *node[K]) {
+	n.height = 1 + max(height(n.left), height(n.right))
+}
+
+func rotateLeftAVL[K cmp.Ordered](n *node[K]) *node[K] {
+	r := n.right
+	n.right, r.left = r.left, n
+	updateHeight(n)
+	updateHeight(r)
+	return r
+}
+
+func rotateRightAVL[K cmp.Ordered](n *node[K]) *node[K] {
+	l := n.left
+	n.left, l.right = l.right, n
+	updateHeight(n)
+	updateHeight(l)
+	return l
+}
+
+// rebalanceAVL restores the AVL invariant at n, whose subtrees
+// are AVL trees differing in height by two at most.
+func rebalanceAVL[K cmp.Ordered](n *node[K]) *node[K] {
+	updateHeight(n)
+	switch balance := height(n.left) - height(n.right); {
+	case balance > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeftAVL(n.left)
+		}
+		return rotateRightAVL(n)
+	case balance < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRightAVL(n.right)
+		}
+		return rotateLeftAVL(n)
+	}
+	return n
+}
@@ -0,0 +1,277 @@
+// Package bst implements binary search trees of ordered keys:
+// a plain one, which shape depends on the insertion order like
+// the trees of tree.New, and the AVL and red-black variants,
+// which stay balanced whatever the order.
+package bst
+
+import (
+	"cmp"
+
+	"golearning/tree"
+)
+
+// Balance is the balancing strategy of a Tree.
+type Balance int
+
+const (
+	// Plain trees are never rebalanced: inserting sorted keys
+	// builds a linked list.
+	Plain Balance = iota
+	// AVL trees keep the heights of the two subtrees of every
+	// node within one of each other.
+	AVL
+	// RedBlack trees are left-leaning red-black trees: no path
+	// from the root to a leaf is twice as long as another.
+	RedBlack
+)
+
+func (b Balance) String() string {
+	switch b {
+	case Plain:
+		return "plain"
+	case AVL:
+		return "AVL"
+	case RedBlack:
+		return "red-black"
+	}
+	return "unknown"
+}
+
+type node[K cmp.Ordered] struct {
+	key         K
+	left, right *node[K]
+	height      int  // of the subtree, maintained by AVL trees only
+	red         bool // color of the link from the parent, for red-black trees
+}
+
+// A Tree is a set of keys stored in a binary search tree.
+// The zero value is an empty Plain tree.
+type Tree[K cmp.Ordered] struct {
+	root    *node[K]
+	size    int
+	balance Balance
+}
+
+// New returns an empty tree balanced by b.
+func New[K cmp.Ordered](b Balance) *Tree[K] {
+	return &Tree[K]{balance: b}
+}
+
+// Balance returns the balancing strategy of t.
+func (t *Tree[K]) Balance() Balance { return t.balance }
+
+// Len returns the number of keys in t.
+func (t *Tree[K]) Len() int { return t.size }
+
+// Height returns the number of nodes on the longest path
+// from the root of t to a leaf, 0 for an empty tree.
+func (t *Tree[K]) Height() int {
+	var height func(n *node[K]) int
+	height = func(n *node[K]) int {
+		if n == nil {
+			return 0
+		}
+		return 1 + max(height(n.left), height(n.right))
+	}
+	return height(t.root)
+}
+
+// Insert adds key to t. It reports whether key was added,
+// that is whether it was not already in t.
+func (t *Tree[K]) Insert(key K) bool {
+	var inserted bool
+	switch t.balance {
+	case AVL:
+		t.root = insert(t.root, key, &inserted, rebalanceAVL[K])
+	case RedBlack:
+		t.root = insertRB(t.root, key, &inserted)
+		t.root.red = false
+	default:
+		t.root = insert(t.root, key, &inserted, nil)
+	}
+	if inserted {
+		t.size++
+	}
+	return inserted
+}
+
+// Delete removes key from t. It reports whether key was in t.
+func (t *Tree[K]) Delete(key K) bool {
+	if !t.Search(key) {
+		return false
+	}
+	switch t.balance {
+	case AVL:
+		t.root = remove(t.root, key, rebalanceAVL[K])
+	case RedBlack:
+		t.root = deleteRBRoot(t.root, key)
+	default:
+		t.root = remove(t.root, key, nil)
+	}
+	t.size--
+	return true
+}
+
+// Search reports whether key is in t.
+func (t *Tree[K]) Search(key K) bool {
+	n := t.root
+	for n != nil {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Min returns the smallest key of t, ok is false if t is empty.
+func (t *Tree[K]) Min() (key K, ok bool) {
+	if t.root == nil {
+		return key, false
+	}
+	return minNode(t.root).key, true
+}
+
+// Max returns the largest key of t, ok is false if t is empty.
+func (t *Tree[K]) Max() (key K, ok bool) {
+	n := t.root
+	if n == nil {
+		return key, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, true
+}
+
+func minNode[K cmp.Ordered](n *node[K]) *node[K] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// insert adds key below n, calling fix, if not nil, on the way
+// back up to rebalance every node of the path.
+func insert[K cmp.Ordered](n *node[K], key K, inserted *bool, fix func(*node[K]) *node[K]) *node[K] {
+	if n == nil {
+		*inserted = true
+		return &node[K]{key: key, height: 1}
+	}
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left = insert(n.left, key, inserted, fix)
+	case c > 0:
+		n.right = insert(n.right, key, inserted, fix)
+	default:
+		return n
+	}
+	if fix == nil {
+		return n
+	}
+	return fix(n)
+}
+
+// remove deletes key, which must be below n, like insert.
+func remove[K cmp.Ordered](n *node[K], key K, fix func(*node[K]) *node[K]) *node[K] {
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left = remove(n.left, key, fix)
+	case c > 0:
+		n.right = remove(n.right, key, fix)
+	default:
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		// Replace the key by its successor, removed from the right.
+		n.key = minNode(n.right).key
+		n.right = remove(n.right, n.key, fix)
+	}
+	if fix == nil {
+		return n
+	}
+	return fix(n)
+}
+
+// InOrder calls visit for each key of t in increasing order,
+// until visit returns false.
+func (t *Tree[K]) InOrder(visit func(key K) bool) {
+	var walk func(n *node[K]) bool
+	walk = func(n *node[K]) bool {
+		return n == nil || walk(n.left) && visit(n.key) && walk(n.right)
+	}
+	walk(t.root)
+}
+
+// PreOrder calls visit for each key of t, a node before its
+// subtrees, until visit returns false.
+func (t *Tree[K]) PreOrder(visit func(key K) bool) {
+	var walk func(n *node[K]) bool
+	walk = func(n *node[K]) bool {
+		return n == nil || visit(n.key) && walk(n.left) && walk(n.right)
+	}
+	walk(t.root)
+}
+
+// PostOrder calls visit for each key of t, a node after its
+// subtrees, until visit returns false.
+func (t *Tree[K]) PostOrder(visit func(key K) bool) {
+	var walk func(n *node[K]) bool
+	walk = func(n *node[K]) bool {
+		return n == nil || walk(n.left) && walk(n.right) && visit(n.key)
+	}
+	walk(t.root)
+}
+
+// LevelOrder calls visit for each key of t, level by level
+// from the root, until visit returns false.
+func (t *Tree[K]) LevelOrder(visit func(key K) bool) {
+	if t.root == nil {
+		return
+	}
+	queue := []*node[K]{t.root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if !visit(n.key) {
+			return
+		}
+		if n.left != nil {
+			queue = append(queue, n.left)
+		}
+		if n.right != nil {
+			queue = append(queue, n.right)
+		}
+	}
+}
+
+// Keys returns the keys of t in increasing order.
+func (t *Tree[K]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	t.InOrder(func(key K) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// TourTree returns a copy of t with the same shape as a
+// *tree.Tree, to use it with tree.Walk, tree.Same, tree.Draw
+// or printTreeComplexImproved.
+func TourTree(t *Tree[int]) *tree.Tree {
+	var copyNode func(n *node[int]) *tree.Tree
+	copyNode = func(n *node[int]) *tree.Tree {
+		if n == nil {
+			return nil
+		}
+		return &tree.Tree{Left: copyNode(n.left), Value: n.key, Right: copyNode(n.right)}
+	}
+	return copyNode(t.root)
+}
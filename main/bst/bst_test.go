@@ -0,0 +1,195 @@
+package bst
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golearning/tree"
+)
+
+var balances = []Balance{Plain, AVL, RedBlack}
+
+func TestRandomOperations(t *testing.T) {
+	for _, b := range balances {
+		r := rand.New(rand.NewSource(1))
+		tr := New[int](b)
+		want := make(map[int]bool)
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(200)
+			if r.Intn(3) == 0 {
+				if got := tr.Delete(key); got != want[key] {
+					t.Fatalf("%v: Delete(%d) = %v, want %v", b, key, got, want[key])
+				}
+				delete(want, key)
+			} else {
+				if got := tr.Insert(key); got == want[key] {
+					t.Fatalf("%v: Insert(%d) = %v, want %v", b, key, got, !want[key])
+				}
+				want[key] = true
+			}
+			if err := tr.Check(); err != nil {
+				t.Fatalf("%v: after %d operations: %v", b, i+1, err)
+			}
+		}
+		if tr.Len() != len(want) {
+			t.Errorf("%v: Len() = %d, want %d", b, tr.Len(), len(want))
+		}
+		var keys []int
+		for key := range want {
+			keys = append(keys, key)
+		}
+		sort.Ints(keys)
+		if got := tr.Keys(); !reflect.DeepEqual(got, keys) {
+			t.Errorf("%v: Keys() = %v, want %v", b, got, keys)
+		}
+		for key := -1; key <= 200; key++ {
+			if got := tr.Search(key); got != want[key] {
+				t.Errorf("%v: Search(%d) = %v, want %v", b, key, got, want[key])
+			}
+		}
+		if min, ok := tr.Min(); !ok || min != keys[0] {
+			t.Errorf("%v: Min() = %d, %v, want %d", b, min, ok, keys[0])
+		}
+		if max, ok := tr.Max(); !ok || max != keys[len(keys)-1] {
+			t.Errorf("%v: Max() = %d, %v, want %d", b, max, ok, keys[len(keys)-1])
+		}
+	}
+}
+
+func TestSortedInsertions(t *testing.T) {
+	const n = 1000
+	limits := map[Balance]float64{
+		Plain:    n,
+		AVL:      1.45 * math.Log2(n+2),
+		RedBlack: 2 * math.Log2(n+1),
+	}
+	for _, b := range balances {
+		tr := New[int](b)
+		for i := 0; i < n; i++ {
+			tr.Insert(i)
+		}
+		if err := tr.Check(); err != nil {
+			t.Errorf("%v: %v", b, err)
+		}
+		if h := tr.Height(); float64(h) > limits[b] {
+			t.Errorf("%v: height %d after %d sorted insertions, want at most %.0f", b, h, n, limits[b])
+		}
+		for i := 0; i < n; i += 2 {
+			tr.Delete(i)
+		}
+		if err := tr.Check(); err != nil {
+			t.Errorf("%v: after deletions: %v", b, err)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	var tr Tree[string]
+	if _, ok := tr.Min(); ok {
+		t.Error("Min() of an empty tree is ok")
+	}
+	if _, ok := tr.Max(); ok {
+		t.Error("Max() of an empty tree is ok")
+	}
+	if tr.Delete("x") || tr.Search("x") || tr.Len() != 0 || tr.Height() != 0 {
+		t.Error("an empty tree is not empty")
+	}
+	if tr.Balance() != Plain {
+		t.Errorf("the zero Tree is %v, want plain", tr.Balance())
+	}
+}
+
+func TestWalks(t *testing.T) {
+	tr := New[int](Plain)
+	for _, key := range []int{4, 2, 6, 1, 3, 5, 7} {
+		tr.Insert(key)
+	}
+	collect := func(walk func(func(int) bool), limit int) []int {
+		var keys []int
+		walk(func(key int) bool {
+			keys = append(keys, key)
+			return len(keys) < limit
+		})
+		return keys
+	}
+	tests := []struct {
+		name string
+		walk func(func(int) bool)
+		want []int
+	}{
+		{"InOrder", tr.InOrder, []int{1, 2, 3, 4, 5, 6, 7}},
+		{"PreOrder", tr.PreOrder, []int{4, 2, 1, 3, 6, 5, 7}},
+		{"PostOrder", tr.PostOrder, []int{1, 3, 2, 5, 7, 6, 4}},
+		{"LevelOrder", tr.LevelOrder, []int{4, 2, 6, 1, 3, 5, 7}},
+	}
+	for _, tt := range tests {
+		if got := collect(tt.walk, 100); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := collect(tt.walk, 3); !reflect.DeepEqual(got, tt.want[:3]) {
+			t.Errorf("%s stopped after 3 keys = %v, want %v", tt.name, got, tt.want[:3])
+		}
+	}
+}
+
+func TestTourTree(t *testing.T) {
+	for _, b := range balances {
+		tr := New[int](b)
+		for _, v := range rand.Perm(10) {
+			tr.Insert((v + 1) * 3)
+		}
+		tt := TourTree(tr)
+		if !tree.Same(tt, tree.New(3)) {
+			t.Errorf("%v: tree.Same(TourTree(t), tree.New(3)) = false for %v", b, tt)
+		}
+		if levels := tree.Levels(tt); len(levels) != tr.Height()+1 {
+			t.Errorf("%v: %d levels for a tree of height %d", b, len(levels), tr.Height())
+		}
+	}
+}
+
+// The benchmarks insert n keys in a random order, as tree.New
+// does, or in increasing order, the worst case of Plain trees.
+
+func benchmarkInsert(b *testing.B, keys []int) {
+	for _, balance := range balances {
+		b.Run(balance.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tr := New[int](balance)
+				for _, key := range keys {
+					tr.Insert(key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkInsertRandom(b *testing.B) {
+	benchmarkInsert(b, rand.New(rand.NewSource(1)).Perm(1000))
+}
+
+func BenchmarkInsertSorted(b *testing.B) {
+	keys := make([]int, 1000)
+	for i := range keys {
+		keys[i] = i
+	}
+	benchmarkInsert(b, keys)
+}
+
+func BenchmarkSearchAfterSortedInsertions(b *testing.B) {
+	for _, balance := range balances {
+		tr := New[int](balance)
+		for i := 0; i < 1000; i++ {
+			tr.Insert(i)
+		}
+		b.Run(fmt.Sprintf("%v/height=%d", balance, tr.Height()), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tr.Search(i % 1000)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package bst
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// Check verifies the invariants of t: the keys are sorted,
+// Len is the number of nodes, and the tree is balanced as
+// its Balance requires. It returns the first violation found.
+func (t *Tree[K]) Check() error {
+	count := 0
+	var previous *K
+	var err error
+	var walk func(n *node[K])
+	walk = func(n *node[K]) {
+		if n == nil || err != nil {
+			return
+		}
+		walk(n.left)
+		if previous != nil && cmp.Compare(*previous, n.key) >= 0 {
+			err = fmt.Errorf("bst: key %v after %v in order", n.key, *previous)
+		}
+		key := n.key
+		previous = &key
+		count++
+		walk(n.right)
+	}
+	walk(t.root)
+	if err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("bst: %d nodes, Len is %d", count, t.size)
+	}
+	switch t.balance {
+	case AVL:
+		_, err = checkAVL(t.root)
+	case RedBlack:
+		if isRed(t.root) {
+			return errors.New("bst: red root")
+		}
+		_, err = checkRB(t.root)
+	}
+	return err
+}
+
+// checkAVL returns the height of the subtree n.
+func checkAVL[K cmp.Ordered](n *node[K]) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	l, err := checkAVL(n.left)
+	if err != nil {
+		return 0, err
+	}
+	r, err := checkAVL(n.right)
+	if err != nil {
+		return 0, err
+	}
+	if l-r > 1 || r-l > 1 {
+		return 0, fmt.Errorf("bst: subtrees of %v have heights %d and %d", n.key, l, r)
+	}
+	if h := 1 + max(l, r); n.height != h {
+		return 0, fmt.Errorf("bst: height of %v is %d, recorded as %d", n.key, h, n.height)
+	}
+	return n.height, nil
+}
+
+// checkRB returns the number of black links from n to any leaf.
+func checkRB[K cmp.Ordered](n *node[K]) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if isRed(n.right) {
+		return 0, fmt.Errorf("bst: red right link below %v", n.key)
+	}
+	if isRed(n) && isRed(n.left) {
+		return 0, fmt.Errorf("bst: two red links in a row at %v", n.key)
+	}
+	l, err := checkRB(n.left)
+	if err != nil {
+		return 0, err
+	}
+	r, err := checkRB(n.right)
+	if err != nil {
+		return 0, err
+	}
+	if l != r {
+		return 0, fmt.Errorf("bst: %d black links on the left of %v, %d on the right", l, n.key, r)
+	}
+	if !isRed(n) {
+		l++
+	}
+	return l, nil
+}
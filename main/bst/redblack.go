@@ -0,0 +1,133 @@
+package bst
+
+import "cmp"
+
+// The red-black trees are the left-leaning variant described
+// by Robert Sedgewick: red links always lean left, so that
+// they map one to one with 2-3 trees.
+
+func isRed[K cmp.Ordered](n *node[K]) bool {
+	return n != nil && n.red
+}
+
+func rotateLeftRB[K cmp.Ordered](n *node[K]) *node[K] {
+	r := n.right
+	n.right, r.left = r.left, n
+	r.red, n.red = n.red, true
+	return r
+}
+
+func rotateRightRB[K cmp.Ordered](n *node[K]) *node[K] {
+	l := n.left
+	n.left, l.right = l.right, n
+	l.red, n.red = n.red, true
+	return l
+}
+
+func flipColors[K cmp.Ordered](n *node[K]) {
+	n.red = !n.red
+	n.left.red = !n.left.red
+	n.right.red = !n.right.red
+}
+
+// fixUpRB restores the left-leaning invariants at n on the
+// way back up from an insertion or a deletion.
+func fixUpRB[K cmp.Ordered](n *node[K]) *node[K] {
+	if isRed(n.right) && !isRed(n.left) {
+		n = rotateLeftRB(n)
+	}
+	if isRed(n.left) && isRed(n.left.left) {
+		n = rotateRightRB(n)
+	}
+	if isRed(n.left) && isRed(n.right) {
+		flipColors(n)
+	}
+	return n
+}
+
+func insertRB[K cmp.Ordered](n *node[K], key K, inserted *bool) *node[K] {
+	if n == nil {
+		*inserted = true
+		return &node[K]{key: key, red: true}
+	}
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left = insertRB(n.left, key, inserted)
+	case c > 0:
+		n.right = insertRB(n.right, key, inserted)
+	}
+	return fixUpRB(n)
+}
+
+// moveRedLeft makes n.left or one of its children red,
+// assuming n is red and both n.left and n.left.left are black.
+func moveRedLeft[K cmp.Ordered](n *node[K]) *node[K] {
+	flipColors(n)
+	if isRed(n.right.left) {
+		n.right = rotateRightRB(n.right)
+		n = rotateLeftRB(n)
+		flipColors(n)
+	}
+	return n
+}
+
+// moveRedRight makes n.right or one of its children red,
+// assuming n is red and both n.right and n.right.left are black.
+func moveRedRight[K cmp.Ordered](n *node[K]) *node[K] {
+	flipColors(n)
+	if isRed(n.left.left) {
+		n = rotateRightRB(n)
+		flipColors(n)
+	}
+	return n
+}
+
+func deleteMinRB[K cmp.Ordered](n *node[K]) *node[K] {
+	if n.left == nil {
+		return nil
+	}
+	if !isRed(n.left) && !isRed(n.left.left) {
+		n = moveRedLeft(n)
+	}
+	n.left = deleteMinRB(n.left)
+	return fixUpRB(n)
+}
+
+// deleteRBRoot deletes key, which must be in the tree of root.
+func deleteRBRoot[K cmp.Ordered](root *node[K], key K) *node[K] {
+	if !isRed(root.left) && !isRed(root.right) {
+		root.red = true
+	}
+	root = deleteRB(root, key)
+	if root != nil {
+		root.red = false
+	}
+	return root
+}
+
+func deleteRB[K cmp.Ordered](n *node[K], key K) *node[K] {
+	if cmp.Less(key, n.key) {
+		if !isRed(n.left) && !isRed(n.left.left) {
+			n = moveRedLeft(n)
+		}
+		n.left = deleteRB(n.left, key)
+		return fixUpRB(n)
+	}
+	if isRed(n.left) {
+		n = rotateRightRB(n)
+	}
+	if cmp.Compare(key, n.key) == 0 && n.right == nil {
+		return nil
+	}
+	if !isRed(n.right) && !isRed(n.right.left) {
+		n = moveRedRight(n)
+	}
+	if cmp.Compare(key, n.key) == 0 {
+		// Replace the key by its successor, removed from the right.
+		n.key = minNode(n.right).key
+		n.right = deleteMinRB(n.right)
+	} else {
+		n.right = deleteRB(n.right, key)
+	}
+	return fixUpRB(n)
+}
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+	"testing/iotest"
+	"time"
+
+	"golearning/tree"
+)
+
+// A check is one test of the battery of an exercise.
+type check struct {
+	name string
+	// hint is shown when the check fails, to help fixing the solution.
+	hint string
+	test func() error
+}
+
+// An exercise is one of the tour exercises solved in this
+// repository. checks returns its battery, bound to the solution.
+type exercise struct {
+	name   string
+	title  string
+	checks func() []check
+}
+
+// exercises is the registry of the solutions the check command grades.
+var exercises = []exercise{
+	{"Pic", "Exercise: Slices", func() []check {
+		return picChecks(Pic)
+	}},
+	{"fibonacci", "Exercise: Fibonacci closure", func() []check {
+		return fibonacciChecks(fibonacci)
+	}},
+	{"sqrtFinder", "Exercise: Loops and Functions", func() []check {
+		return sqrtFinderChecks(func(x float64) float64 {
+			return sqrtFinder(newContext(io.Discard), x)
+		})
+	}},
+	{"Sqrt", "Exercise: Errors", func() []check {
+		return sqrtChecks(Sqrt)
+	}},
+	{"MyReader", "Exercise: Readers", func() []check {
+		return myReaderChecks(MyReader{})
+	}},
+	{"rot13Reader", "Exercise: rot13Reader", func() []check {
+		return rot13Checks(func(r io.Reader) io.Reader { return &rot13Reader{r} })
+	}},
+	{"Image", "Exercise: Images", func() []check {
+		return imageChecks(func(w, h int) image.Image { return Image{Width: w, Height: h} })
+	}},
+	{"trees", "Exercise: Equivalent Binary Trees", func() []check {
+		return treeChecks(
+			func(t *tree.Tree, ch chan int) { tree.Walk(context.Background(), t, ch) },
+			tree.Same,
+		)
+	}},
+}
+
+func findExercise(name string) (exercise, bool) {
+	for _, e := range exercises {
+		if e.name == name {
+			return e, true
+		}
+	}
+	return exercise{}, false
+}
+
+// checkTimeout bounds each check, since a wrong solution
+// may block forever on a channel or loop endlessly.
+const checkTimeout = 2 * time.Second
+
+// runCheck runs c, turning a panic or a timeout into an error.
+// A check that times out is left running in the background.
+func runCheck(c check) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- c.test()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(checkTimeout):
+		return fmt.Errorf("did not finish within %v", checkTimeout)
+	}
+}
+
+// grade runs the battery of e and reports every check to w.
+// It returns the number of failed checks.
+func grade(w io.Writer, e exercise) (failed int) {
+	fmt.Fprintf(w, "%s: %s\n", e.name, e.title)
+	checks := e.checks()
+	for _, c := range checks {
+		if err := runCheck(c); err != nil {
+			failed++
+			fmt.Fprintf(w, "  FAIL %s\n", c.name)
+			fmt.Fprintf(w, "       %v\n", err)
+			fmt.Fprintf(w, "       hint: %s\n", c.hint)
+		} else {
+			fmt.Fprintf(w, "  ok   %s\n", c.name)
+		}
+	}
+	fmt.Fprintf(w, "  %d/%d checks passed\n", len(checks)-failed, len(checks))
+	return failed
+}
+
+func picChecks(pic func(dx, dy int) [][]uint8) []check {
+	return []check{{
+		name: "returns dy rows of dx values",
+		hint: "make the outer slice with make([][]uint8, dy) and each row with make([]uint8, dx)",
+		test: func() error {
+			for _, size := range [][2]int{{256, 256}, {7, 3}, {1, 1}} {
+				dx, dy := size[0], size[1]
+				rows := pic(dx, dy)
+				if len(rows) != dy {
+					return fmt.Errorf("Pic(%d, %d) has %d rows", dx, dy, len(rows))
+				}
+				for y, row := range rows {
+					if len(row) != dx {
+						return fmt.Errorf("Pic(%d, %d) row %d has %d values", dx, dy, y, len(row))
+					}
+				}
+			}
+			return nil
+		},
+	}, {
+		name: "draws a pattern",
+		hint: "compute each value from x and y, e.g. (x+y)/2, x*y or x^y",
+		test: func() error {
+			rows := pic(256, 256)
+			for _, row := range rows {
+				for _, v := range row {
+					if v != rows[0][0] {
+						return nil
+					}
+				}
+			}
+			return fmt.Errorf("every value is %d", rows[0][0])
+		},
+	}}
+}
+
+func fibonacciChecks(fibonacci func() func() int) []check {
+	return []check{{
+		name: "returns 0, 1, 1, 2, 3, 5, 8, 13, 21, 34",
+		hint: "return the current number before moving to the next one, the first call returns 0",
+		test: func() error {
+			want := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}
+			f := fibonacci()
+			for i, w := range want {
+				if got := f(); got != w {
+					return fmt.Errorf("call %d returned %d, want %d", i+1, got, w)
+				}
+			}
+			return nil
+		},
+	}, {
+		name: "closures do not share their state",
+		hint: "declare the state inside fibonacci so that each closure has its own",
+		test: func() error {
+			first := fibonacci()()
+			f := fibonacci()
+			for i := 0; i < 5; i++ {
+				f()
+			}
+			if got := fibonacci()(); got != first {
+				return fmt.Errorf("a new closure starts at %d, the first one started at %d", got, first)
+			}
+			return nil
+		},
+	}}
+}
+
+func sqrtFinderChecks(sqrt func(x float64) float64) []check {
+	return []check{{
+		name: "is close to math.Sqrt",
+		hint: "repeat z -= (z*z - x) / (2*z) until z barely changes",
+		test: func() error {
+			for _, x := range []float64{0.25, 1, 2, 9, 81, 100} {
+				if got, want := sqrt(x), math.Sqrt(x); math.Abs(got-want) > 1e-3*want {
+					return fmt.Errorf("sqrtFinder(%g) = %g, want %g", x, got, want)
+				}
+			}
+			return nil
+		},
+	}}
+}
+
+func sqrtChecks(sqrt func(x float64) (float64, error)) []check {
+	return []check{{
+		name: "returns the square root of non-negative numbers",
+		hint: "for x >= 0, compute the root with the Newton loop of sqrtFinder",
+		test: func() error {
+			for _, x := range []float64{0, 2, 81} {
+				got, err := sqrt(x)
+				if err != nil {
+					return fmt.Errorf("Sqrt(%g) returned the error %q", x, err)
+				}
+				if want := math.Sqrt(x); math.Abs(got-want) > 1e-3*want {
+					return fmt.Errorf("Sqrt(%g) = %g, want %g", x, got, want)
+				}
+			}
+			return nil
+		},
+	}, {
+		name: "returns an error for negative numbers",
+		hint: "return a non-nil error, like ErrNegativeSqrt(x), when x < 0",
+		test: func() error {
+			if _, err := sqrt(-2); err == nil {
+				return errors.New("Sqrt(-2) returned a nil error")
+			}
+			return nil
+		},
+	}, {
+		name: "the error message tells which number was negative",
+		hint: `format the message like "cannot Sqrt negative number: -2"`,
+		test: func() error {
+			_, err := sqrt(-2)
+			if err == nil || !strings.Contains(err.Error(), "cannot Sqrt negative number: -2") {
+				return fmt.Errorf("Sqrt(-2) returned the error %q", err)
+			}
+			return nil
+		},
+	}}
+}
+
+func myReaderChecks(r io.Reader) []check {
+	return []check{{
+		name: "emits an infinite stream of 'A'",
+		hint: "fill the whole buffer with 'A' and return len(b), nil",
+		test: func() error {
+			for _, size := range []int{1, 10, 4096} {
+				b := make([]byte, size)
+				for i := 0; i < 100; i++ {
+					n, err := r.Read(b)
+					if err != nil {
+						return fmt.Errorf("Read returned the error %v", err)
+					}
+					if n == 0 {
+						return fmt.Errorf("Read of %d bytes returned 0 bytes", size)
+					}
+					if i := bytes.IndexFunc(b[:n], func(r rune) bool { return r != 'A' }); i >= 0 {
+						return fmt.Errorf("Read returned %q at offset %d", b[i], i)
+					}
+				}
+			}
+			return nil
+		},
+	}}
+}
+
+func rot13Checks(newReader func(r io.Reader) io.Reader) []check {
+	rot13 := func(s string) (string, error) {
+		b, err := io.ReadAll(newReader(strings.NewReader(s)))
+		return string(b), err
+	}
+	return []check{{
+		name: "decodes the tour's message",
+		hint: "shift each letter by 13 places in its own case, wrapping around after z",
+		test: func() error {
+			const msg, want = "Lbh penpxrq gur pbqr!", "You cracked the code!"
+			if got, err := rot13(msg); err != nil || got != want {
+				return fmt.Errorf("decoding %q gave %q, %v", msg, got, err)
+			}
+			return nil
+		},
+	}, {
+		name: "applied twice gives back the input",
+		hint: "only letters move, by 13 places out of 26, so rot13(rot13(b)) == b",
+		test: func() error {
+			all := make([]byte, 256)
+			for i := range all {
+				all[i] = byte(i)
+			}
+			once, err := rot13(string(all))
+			if err != nil {
+				return err
+			}
+			twice, err := rot13(once)
+			if err != nil {
+				return err
+			}
+			for i := range all {
+				if twice[i] != all[i] {
+					return fmt.Errorf("byte %q became %q", all[i], twice[i])
+				}
+			}
+			return nil
+		},
+	}, {
+		name: "behaves like an io.Reader",
+		hint: "read from the wrapped reader first, then transform b[:n] and return its n and err unchanged",
+		test: func() error {
+			const msg = "Why did the chicken cross the road?"
+			const want = "Jul qvq gur puvpxra pebff gur ebnq?"
+			return iotest.TestReader(newReader(iotest.HalfReader(strings.NewReader(msg))), []byte(want))
+		},
+	}}
+}
+
+func imageChecks(newImage func(w, h int) image.Image) []check {
+	return []check{{
+		name: "Bounds is the rectangle (0,0)-(w,h)",
+		hint: "return image.Rect(0, 0, w, h)",
+		test: func() error {
+			if got, want := newImage(100, 50).Bounds(), image.Rect(0, 0, 100, 50); got != want {
+				return fmt.Errorf("Bounds() = %v, want %v", got, want)
+			}
+			return nil
+		},
+	}, {
+		name: "At returns colors of the ColorModel",
+		hint: "return the color type matching ColorModel, e.g. color.RGBA for color.RGBAModel",
+		test: func() error {
+			m := newImage(100, 50)
+			for _, p := range []image.Point{{0, 0}, {10, 20}, {99, 49}} {
+				c := m.At(p.X, p.Y)
+				if c == nil {
+					return fmt.Errorf("At(%d, %d) is nil", p.X, p.Y)
+				}
+				if m.ColorModel().Convert(c) != c {
+					return fmt.Errorf("At(%d, %d) = %#v is not a color of the ColorModel", p.X, p.Y, c)
+				}
+			}
+			return nil
+		},
+	}, {
+		name: "can be encoded as a PNG",
+		hint: "pic.ShowImage encodes the image with image/png, every method must work",
+		test: func() error {
+			return png.Encode(io.Discard, newImage(100, 50))
+		},
+	}}
+}
+
+func treeChecks(walk func(t *tree.Tree, ch chan int), same func(t1, t2 *tree.Tree) bool) []check {
+	return []check{{
+		name: "Walk sends the values of the tree in order",
+		hint: "walk the left subtree, send the value, then walk the right subtree",
+		test: func() error {
+			ch := make(chan int)
+			go walk(tree.New(1), ch)
+			for want := 1; want <= 10; want++ {
+				if got := <-ch; got != want {
+					return fmt.Errorf("value %d is %d, want %d", want, got, want)
+				}
+			}
+			return nil
+		},
+	}, {
+		name: "Walk closes the channel at the end",
+		hint: "close(ch) once the whole tree is walked, so the receiver can range over ch",
+		test: func() error {
+			ch := make(chan int)
+			go walk(tree.New(1), ch)
+			n := 0
+			for range ch {
+				n++
+			}
+			if n != 10 {
+				return fmt.Errorf("received %d values, want 10", n)
+			}
+			return nil
+		},
+	}, {
+		name: "Same is true for trees with the same values",
+		hint: "walk both trees and compare their values one by one",
+		test: func() error {
+			for k := 1; k <= 5; k++ {
+				if !same(tree.New(k), tree.New(k)) {
+					return fmt.Errorf("Same(tree.New(%d), tree.New(%d)) = false", k, k)
+				}
+			}
+			return nil
+		},
+	}, {
+		name: "Same is false for trees with different values",
+		hint: "return false at the first pair of values that differ",
+		test: func() error {
+			for k := 1; k <= 5; k++ {
+				if same(tree.New(k), tree.New(k+1)) {
+					return fmt.Errorf("Same(tree.New(%d), tree.New(%d)) = true", k, k+1)
+				}
+			}
+			return nil
+		},
+	}}
+}
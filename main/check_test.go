@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golearning/tree"
+)
+
+// failed returns the names of the checks that fail.
+func failed(checks []check) []string {
+	var names []string
+	for _, c := range checks {
+		if runCheck(c) != nil {
+			names = append(names, c.name)
+		}
+	}
+	return names
+}
+
+func refFibonacci() func() int {
+	a, b := 0, 1
+	return func() int {
+		f := a
+		a, b = b, a+b
+		return f
+	}
+}
+
+var sharedFib int
+
+func sharedFibonacci() func() int {
+	return func() int {
+		sharedFib++
+		return sharedFib
+	}
+}
+
+func refWalk(t *tree.Tree, ch chan int) {
+	var walk func(t *tree.Tree)
+	walk = func(t *tree.Tree) {
+		if t == nil {
+			return
+		}
+		walk(t.Left)
+		ch <- t.Value
+		walk(t.Right)
+	}
+	walk(t)
+	close(ch)
+}
+
+func refSame(t1, t2 *tree.Tree) bool {
+	c1, c2 := make(chan int), make(chan int)
+	go refWalk(t1, c1)
+	go refWalk(t2, c2)
+	for x := range c1 {
+		if x != <-c2 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSqrt(t *testing.T) {
+	for _, x := range []float64{0, 1, 2, 3, 81, 0.1, 1e-310, 1e-300, 12345.678, 1e300, math.MaxFloat64, math.Inf(1)} {
+		if got, err := Sqrt(x); err != nil || got != math.Sqrt(x) {
+			t.Errorf("Sqrt(%g) = %v, %v, want %v", x, got, err, math.Sqrt(x))
+		}
+	}
+}
+
+func TestCheckBatteries(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []check
+		failed []string
+	}{
+		{"Pic", picChecks(Pic), nil},
+		{"constant Pic", picChecks(func(dx, dy int) [][]uint8 {
+			rows := make([][]uint8, dy)
+			for y := range rows {
+				rows[y] = make([]uint8, dx)
+			}
+			return rows
+		}), []string{"draws a pattern"}},
+		{"transposed Pic", picChecks(func(dx, dy int) [][]uint8 {
+			return Pic(dy, dx)
+		}), []string{"returns dy rows of dx values"}},
+
+		{"reference fibonacci", fibonacciChecks(refFibonacci), nil},
+		{"shared fibonacci", fibonacciChecks(sharedFibonacci), []string{
+			"returns 0, 1, 1, 2, 3, 5, 8, 13, 21, 34",
+			"closures do not share their state",
+		}},
+
+		{"math.Sqrt", sqrtFinderChecks(math.Sqrt), nil},
+		{"math.Cbrt", sqrtFinderChecks(math.Cbrt), []string{"is close to math.Sqrt"}},
+
+		{"reference Sqrt", sqrtChecks(func(x float64) (float64, error) {
+			if x < 0 {
+				return 0, errors.New("cannot Sqrt negative number: -2")
+			}
+			return math.Sqrt(x), nil
+		}), nil},
+		{"math.Sqrt without error", sqrtChecks(func(x float64) (float64, error) {
+			return math.Sqrt(x), nil
+		}), []string{
+			"returns an error for negative numbers",
+			"the error message tells which number was negative",
+		}},
+
+		{"MyReader", myReaderChecks(MyReader{}), nil},
+		{"finite reader", myReaderChecks(strings.NewReader(strings.Repeat("A", 100))), []string{
+			"emits an infinite stream of 'A'",
+		}},
+
+		{"rot13Reader", rot13Checks(func(r io.Reader) io.Reader { return &rot13Reader{r} }), nil},
+		{"identity reader", rot13Checks(func(r io.Reader) io.Reader { return r }), []string{
+			"decodes the tour's message",
+			"behaves like an io.Reader",
+		}},
+
+		{"Image", imageChecks(func(w, h int) image.Image { return Image{Width: w, Height: h} }), nil},
+		{"transposed Image", imageChecks(func(w, h int) image.Image { return Image{Width: h, Height: w} }), []string{
+			"Bounds is the rectangle (0,0)-(w,h)",
+		}},
+
+		{"reference trees", treeChecks(refWalk, refSame), nil},
+		{"tree package", treeChecks(func(t *tree.Tree, ch chan int) { tree.Walk(context.Background(), t, ch) }, tree.Same), nil},
+		{"unclosed Walk", treeChecks(func(t *tree.Tree, ch chan int) {
+			for v := 1; v <= 10; v++ {
+				ch <- v * t.Value / t.Value
+			}
+		}, func(t1, t2 *tree.Tree) bool { return true }), []string{
+			"Walk closes the channel at the end",
+			"Same is false for trees with different values",
+		}},
+	}
+	for _, tt := range tests {
+		if got := failed(tt.checks); !reflect.DeepEqual(got, tt.failed) {
+			t.Errorf("%s: failed checks %q, want %q", tt.name, got, tt.failed)
+		}
+	}
+}
+
+func TestRunCheckRecoversPanics(t *testing.T) {
+	err := runCheck(check{test: func() error { panic("boom") }})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("runCheck of a panicking check = %v, want the panic as an error", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Package cipher generalizes the rot13Reader exercise of the tour:
+// classical ciphers (Caesar, ROT13, ROT47, Atbash and Vigenère)
+// applied rune by rune to streams of UTF-8 text, by readers and
+// writers wrapping other ones.
+package cipher
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// A Mapping maps each rune of a text to the rune replacing it.
+// Mappings of ciphers with a key, like Vigenère, have a state:
+// a Mapping must only be used for one text.
+type Mapping func(rune) rune
+
+// A Cipher returns the mappings encoding and decoding texts.
+// Each call returns a new mapping, for a new text.
+type Cipher interface {
+	Encoder() Mapping
+	Decoder() Mapping
+}
+
+// Encode returns s encoded with c.
+func Encode(c Cipher, s string) string {
+	return strings.Map(c.Encoder(), s)
+}
+
+// Decode returns s decoded with c.
+func Decode(c Cipher, s string) string {
+	return strings.Map(c.Decoder(), s)
+}
+
+// shift shifts the ASCII letter r by n letters in the alphabet,
+// keeping its case. Other runes are left unchanged.
+func shift(r rune, n int) rune {
+	switch {
+	case 'a' <= r && r <= 'z':
+		return 'a' + rune(mod(int(r-'a')+n, 26))
+	case 'A' <= r && r <= 'Z':
+		return 'A' + rune(mod(int(r-'A')+n, 26))
+	}
+	return r
+}
+
+func isLetter(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z'
+}
+
+func mod(a, n int) int {
+	a %= n
+	if a < 0 {
+		a += n
+	}
+	return a
+}
+
+// Caesar is the cipher shifting each ASCII letter by the
+// same number of letters in the alphabet.
+type Caesar int
+
+// ROT13 is the Caesar cipher of rot13Reader: encoding and
+// decoding are the same.
+const ROT13 = Caesar(13)
+
+func (c Caesar) Encoder() Mapping {
+	return func(r rune) rune { return shift(r, int(c)) }
+}
+
+func (c Caesar) Decoder() Mapping {
+	return func(r rune) rune { return shift(r, -int(c)) }
+}
+
+type rot47 struct{}
+
+// ROT47 rotates the 94 printable ASCII characters, from '!'
+// to '~', by 47 places. Encoding and decoding are the same.
+var ROT47 Cipher = rot47{}
+
+func (rot47) Encoder() Mapping {
+	return func(r rune) rune {
+		if '!' <= r && r <= '~' {
+			return '!' + (r-'!'+47)%94
+		}
+		return r
+	}
+}
+
+func (c rot47) Decoder() Mapping {
+	return c.Encoder()
+}
+
+type atbash struct{}
+
+// Atbash reverses the alphabet, mapping 'a' to 'z', 'b' to 'y'
+// and so on. Encoding and decoding are the same.
+var Atbash Cipher = atbash{}
+
+func (atbash) Encoder() Mapping {
+	return func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return 'z' - (r - 'a')
+		case 'A' <= r && r <= 'Z':
+			return 'Z' - (r - 'A')
+		}
+		return r
+	}
+}
+
+func (c atbash) Decoder() Mapping {
+	return c.Encoder()
+}
+
+// Vigenere is the Vigenère cipher: each ASCII letter is shifted
+// by the next letter of the key, 'a' shifting by 0, 'b' by 1
+// and so on, the key starting over when exhausted. The runes
+// that are not ASCII letters do not use the key.
+type Vigenere struct {
+	shifts []int
+}
+
+// NewVigenere returns the Vigenère cipher of key, made of
+// ASCII letters only, in any case.
+func NewVigenere(key string) (*Vigenere, error) {
+	if key == "" {
+		return nil, errors.New("cipher: empty Vigenère key")
+	}
+	v := &Vigenere{}
+	for _, r := range key {
+		if !isLetter(r) {
+			return nil, errors.New("cipher: Vigenère key with a rune other than an ASCII letter")
+		}
+		v.shifts = append(v.shifts, int(unicode.ToLower(r)-'a'))
+	}
+	return v, nil
+}
+
+func (v *Vigenere) Encoder() Mapping {
+	return v.mapping(1)
+}
+
+func (v *Vigenere) Decoder() Mapping {
+	return v.mapping(-1)
+}
+
+func (v *Vigenere) mapping(sign int) Mapping {
+	i := 0
+	return func(r rune) rune {
+		if !isLetter(r) {
+			return r
+		}
+		r = shift(r, sign*v.shifts[i])
+		i = (i + 1) % len(v.shifts)
+		return r
+	}
+}
@@ -0,0 +1,174 @@
+package cipher
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"testing/quick"
+)
+
+func vigenere(t *testing.T, key string) *Vigenere {
+	t.Helper()
+	v, err := NewVigenere(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Cipher
+		in   string
+		want string
+	}{
+		{"ROT13", ROT13, "Lbh penpxrq gur pbqr!", "You cracked the code!"},
+		{"Caesar(3)", Caesar(3), "Veni, vidi, vici. Zut", "Yhql, ylgl, ylfl. Cxw"},
+		{"Caesar(-1)", Caesar(-1), "abc ABC", "zab ZAB"},
+		{"Caesar(27)", Caesar(27), "abc", "bcd"},
+		{"ROT47", ROT47, "Hello, World!", "w6==@[ (@C=5P"},
+		{"Atbash", Atbash, "Wizard of Oz", "Draziw lu La"},
+		{"Vigenère", vigenere(t, "LEMON"), "ATTACK AT DAWN", "LXFOPV EF RNHR"},
+		{"Vigenère with other runes", vigenere(t, "key"), "héllo, wörld", "répjy, aöpvh"},
+		{"UTF-8", ROT13, "café 世界 🎉", "pnsé 世界 🎉"},
+	}
+	for _, tt := range tests {
+		if got := Encode(tt.c, tt.in); got != tt.want {
+			t.Errorf("%s: Encode(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+		if got := Decode(tt.c, tt.want); got != tt.in {
+			t.Errorf("%s: Decode(%q) = %q, want %q", tt.name, tt.want, got, tt.in)
+		}
+	}
+}
+
+func TestNewVigenere(t *testing.T) {
+	for _, key := range []string{"", "a b", "clé", "k3y"} {
+		if _, err := NewVigenere(key); err == nil {
+			t.Errorf("NewVigenere(%q) returned no error", key)
+		}
+	}
+}
+
+// ciphers returns a cipher of each kind, with keys drawn from r.
+func ciphers(r *rand.Rand) map[string]Cipher {
+	key := make([]byte, 1+r.Intn(10))
+	for i := range key {
+		key[i] = byte('a' + r.Intn(26))
+	}
+	v, _ := NewVigenere(string(key))
+	return map[string]Cipher{
+		"Caesar":   Caesar(r.Intn(100) - 50),
+		"ROT13":    ROT13,
+		"ROT47":    ROT47,
+		"Atbash":   Atbash,
+		"Vigenère": v,
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for name, c := range ciphers(r) {
+		c := c
+		roundTrip := func(s string) bool {
+			return Decode(c, Encode(c, s)) == s
+		}
+		if err := quick.Check(roundTrip, nil); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+// chunkReader returns the bytes of data in chunks of random sizes,
+// cutting runes.
+type chunkReader struct {
+	data []byte
+	r    *rand.Rand
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	if len(c.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p[:min(len(p), 1+c.r.Intn(5))], c.data)
+	c.data = c.data[n:]
+	return n, nil
+}
+
+// TestStreamRoundTrip encodes random bytes, not always valid UTF-8,
+// with a reader cutting them anywhere, and decodes them with a writer
+// written in random chunks: the bytes must come back unchanged.
+func TestStreamRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for name, c := range ciphers(r) {
+		c := c
+		roundTrip := func(data []byte, seed int64) bool {
+			rnd := rand.New(rand.NewSource(seed))
+			enc := NewReader(&chunkReader{data, rnd}, c.Encoder())
+			var out bytes.Buffer
+			dec := NewWriter(&out, c.Decoder())
+			buf := make([]byte, 7)
+			for {
+				n, err := enc.Read(buf[:1+rnd.Intn(len(buf))])
+				dec.Write(buf[:n])
+				if err == io.EOF {
+					break
+				}
+			}
+			dec.Close()
+			return bytes.Equal(out.Bytes(), data)
+		}
+		if err := quick.Check(roundTrip, nil); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestReaderCutRunes(t *testing.T) {
+	const in = "héllo, 世界! Zoë"
+	for name, r := range map[string]io.Reader{
+		"OneByteReader": iotest.OneByteReader(strings.NewReader(in)),
+		"HalfReader":    iotest.HalfReader(strings.NewReader(in)),
+		"DataErrReader": iotest.DataErrReader(strings.NewReader(in)),
+	} {
+		got, err := io.ReadAll(NewReader(r, ROT13.Encoder()))
+		if want := "uéyyb, 世界! Mbë"; err != nil || string(got) != want {
+			t.Errorf("%s: read %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if err := iotest.TestReader(NewReader(strings.NewReader(in), Atbash.Encoder()), []byte(Encode(Atbash, in))); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReaderError(t *testing.T) {
+	r := NewReader(iotest.TimeoutReader(strings.NewReader("abcdef")), ROT13.Encoder())
+	buf := make([]byte, 64)
+	if n, err := r.Read(buf); n != 6 || err != nil {
+		t.Errorf("first Read = %d, %v", n, err)
+	}
+	if _, err := r.Read(buf); err != iotest.ErrTimeout {
+		t.Errorf("second Read error = %v, want %v", err, iotest.ErrTimeout)
+	}
+}
+
+func TestWriterCutRunes(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out, ROT13.Decoder())
+	data := []byte("uéyyb, 世界")
+	for i := range data {
+		w.Write(data[i : i+1])
+	}
+	// A rune cut by the end of the text is written unchanged.
+	w.Write([]byte("!\xe4\xb8"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "héllo, 世界!\xe4\xb8"; got != want {
+		t.Errorf("wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package cipher
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// translate appends to dst the runes of src mapped by f, and
+// returns the extended dst and the number of bytes of src used.
+// A rune cut at the end of src is left for the next call, unless
+// atEOF. Bytes that are not valid UTF-8 are copied unchanged.
+func translate(dst, src []byte, f Mapping, atEOF bool) ([]byte, int) {
+	n := 0
+	for n < len(src) {
+		if !atEOF && !utf8.FullRune(src[n:]) {
+			break
+		}
+		r, size := utf8.DecodeRune(src[n:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, src[n])
+		} else {
+			dst = utf8.AppendRune(dst, f(r))
+		}
+		n += size
+	}
+	return dst, n
+}
+
+type reader struct {
+	r   io.Reader
+	f   Mapping
+	buf [4096]byte
+	in  []byte // bytes read but not translated yet: a cut rune
+	out []byte // bytes translated but not returned yet
+	err error
+}
+
+// NewReader returns a reader mapping the UTF-8 text read from r
+// with f, like NewReader(r, c.Encoder()) to encode it with c.
+// A rune cut between two reads of r is mapped when complete.
+func NewReader(r io.Reader, f Mapping) io.Reader {
+	return &reader{r: r, f: f}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		n, err := r.r.Read(r.buf[:])
+		r.in = append(r.in, r.buf[:n]...)
+		r.err = err
+		var used int
+		r.out, used = translate(r.out[:0], r.in, r.f, err != nil)
+		r.in = r.in[:copy(r.in, r.in[used:])]
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+type writer struct {
+	w   io.Writer
+	f   Mapping
+	in  []byte // a rune cut at the end of the last write
+	out []byte
+}
+
+// NewWriter returns a writer mapping with f the UTF-8 text written
+// to it, like NewWriter(w, c.Decoder()) to decode it with c, before
+// writing it to w. A rune cut between two writes is mapped when
+// complete: Close writes what is left of a cut rune, and must be
+// called after the last write. Close does not close w.
+func NewWriter(w io.Writer, f Mapping) io.WriteCloser {
+	return &writer{w: w, f: f}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	return w.write(p, false)
+}
+
+func (w *writer) write(p []byte, atEOF bool) (int, error) {
+	src := p
+	if len(w.in) > 0 {
+		src = append(w.in, p...)
+	}
+	var used int
+	w.out, used = translate(w.out[:0], src, w.f, atEOF)
+	w.in = append(w.in[:0], src[used:]...)
+	if _, err := w.w.Write(w.out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *writer) Close() error {
+	if len(w.in) == 0 {
+		return nil
+	}
+	_, err := w.write(nil, true)
+	return err
+}
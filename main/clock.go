@@ -0,0 +1,35 @@
+package main
+
+import (
+	"math/rand"
+	"time"
+)
+
+// A Clock tells the time to the steps that depend on it,
+// such as FindSaturday or the errors of run.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// A fixedClock is a Clock where time stands still,
+// to show "Today." or "Good evening." on demand.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+// Random is the source of randomness of the steps,
+// a *rand.Rand satisfies it.
+type Random interface {
+	Intn(n int) int
+	Perm(n int) []int
+}
+
+// newRandom returns a Random that always draws
+// the same numbers for the same seed.
+func newRandom(seed int64) Random {
+	return rand.New(rand.NewSource(seed))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindSaturday(t *testing.T) {
+	tests := []struct {
+		day  int // of March 2024, the 9th is a Saturday
+		want string
+	}{
+		{9, "Today."},
+		{8, "Tomorrow."},
+		{7, "In two days."},
+		{6, "Too far away."},
+		{10, "Too far away."},
+	}
+	for _, tt := range tests {
+		clock := fixedClock(time.Date(2024, 3, tt.day, 12, 0, 0, 0, time.UTC))
+		if got := FindSaturday(clock); got != tt.want {
+			t.Errorf("FindSaturday on March %d = %q, want %q", tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestGreetings(t *testing.T) {
+	tests := []struct {
+		hour int
+		want string
+	}{
+		{0, "Good morning!"},
+		{11, "Good morning!"},
+		{12, "Good afternoon."},
+		{16, "Good afternoon."},
+		{17, "Good evening."},
+		{23, "Good evening."},
+	}
+	for _, tt := range tests {
+		clock := fixedClock(time.Date(2024, 3, 9, tt.hour, 30, 0, 0, time.UTC))
+		if got := greetings(clock); got != tt.want {
+			t.Errorf("greetings at %d:30 = %q, want %q", tt.hour, got, tt.want)
+		}
+	}
+}
+
+func TestNewRandomIsReproducible(t *testing.T) {
+	r1, r2 := newRandom(42), newRandom(42)
+	for i := 0; i < 10; i++ {
+		if a, b := r1.Intn(100), r2.Intn(100); a != b {
+			t.Fatalf("draw %d: %d != %d with the same seed", i, a, b)
+		}
+	}
+}
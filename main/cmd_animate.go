@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image/gif"
+	"os"
+	"strings"
+
+	"golearning/animate"
+	"golearning/gallery"
+	"golearning/render"
+)
+
+const animateUsage = "golearning animate [--size WxH] [--frames n] [--delay n] [--colors n] [--dither] [--colormap name] [--o file] <animation>"
+
+func animationNames() []string {
+	var names []string
+	for _, a := range animate.Animations {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
+// animateGIF writes an animation to a GIF file.
+func animateGIF(args []string) error {
+	fs := flag.NewFlagSet("animate", flag.ContinueOnError)
+	size := fs.String("size", "200x200", "size of the frames, `WxH`")
+	frames := fs.Int("frames", 24, "number of frames")
+	delay := fs.Int("delay", 8, "delay between frames, in hundredths of a second")
+	colors := fs.Int("colors", 256, "number of colors of the palette, at most 256")
+	dither := fs.Bool("dither", false, "dither the frames to the palette")
+	colormap := fs.String("colormap", "", "color the levels with `name`: "+strings.Join(render.ColormapNames(), ", "))
+	output := fs.String("o", "", "write to `file` (default <animation>.gif)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage:", animateUsage)
+		fmt.Fprintln(fs.Output(), "animations:", strings.Join(animationNames(), ", "))
+		fs.PrintDefaults()
+	}
+	// Flags are accepted both before and after the animation.
+	err := fs.Parse(args)
+	name := fs.Arg(0)
+	if err == nil {
+		err = fs.Parse(fs.Args()[min(1, fs.NArg()):])
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if name == "" || fs.NArg() != 0 {
+		return errors.New("usage: " + animateUsage)
+	}
+	w, h, err := parseSize(*size)
+	if err != nil {
+		return err
+	}
+	c := gallery.Canvas{Width: w, Height: h}
+	if *colormap != "" {
+		var ok bool
+		if c.Colormap, ok = render.Colormaps[*colormap]; !ok {
+			return fmt.Errorf("unknown colormap %q", *colormap)
+		}
+	}
+	a, ok := animate.Lookup(name, c)
+	if !ok {
+		return fmt.Errorf("unknown animation %q, want one of %s", name, strings.Join(animationNames(), ", "))
+	}
+	if *output == "" {
+		*output = strings.ToLower(name) + ".gif"
+	}
+
+	g := animate.GIF(a, &animate.Options{Frames: *frames, Delay: *delay, Colors: *colors, Dither: *dither})
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(f, g); err != nil {
+		f.Close()
+		return err
+	}
+	fmt.Println("wrote", *output)
+	return f.Close()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"golearning/cipher"
+)
+
+const cipherUsage = "golearning cipher [--decode] [--shift n] [--key key] caesar|rot13|rot47|atbash|vigenere"
+
+// newCipher returns the cipher called name.
+func newCipher(name string, shift int, key string) (cipher.Cipher, error) {
+	switch name {
+	case "caesar":
+		return cipher.Caesar(shift), nil
+	case "rot13":
+		return cipher.ROT13, nil
+	case "rot47":
+		return cipher.ROT47, nil
+	case "atbash":
+		return cipher.Atbash, nil
+	case "vigenere":
+		return cipher.NewVigenere(key)
+	}
+	return nil, fmt.Errorf("unknown cipher %q", name)
+}
+
+// encipher encodes, or decodes, the standard input to the standard output.
+func encipher(args []string) error {
+	fs := flag.NewFlagSet("cipher", flag.ContinueOnError)
+	decode := fs.Bool("decode", false, "decode the input instead of encoding it")
+	shift := fs.Int("shift", 3, "shift the letters by `n` places (caesar)")
+	key := fs.String("key", "", "the `key` of the cipher, made of letters (vigenere)")
+	// Flags are accepted both before and after the cipher.
+	err := fs.Parse(args)
+	name := fs.Arg(0)
+	if err == nil {
+		err = fs.Parse(fs.Args()[min(1, fs.NArg()):])
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if name == "" || fs.NArg() != 0 {
+		return errors.New("usage: " + cipherUsage)
+	}
+	c, err := newCipher(name, *shift, *key)
+	if err != nil {
+		return err
+	}
+
+	mapping := c.Encoder()
+	if *decode {
+		mapping = c.Decoder()
+	}
+	_, err = io.Copy(os.Stdout, cipher.NewReader(os.Stdin, mapping))
+	return err
+}
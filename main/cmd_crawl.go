@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"golearning/crawler"
+)
+
+const crawlUsage = "golearning crawl [--depth n] [--fake] [<url>]"
+
+func crawl(args []string) error {
+	fs := flag.NewFlagSet("crawl", flag.ContinueOnError)
+	depth := fs.Int("depth", 4, "follow links up to `n` pages deep")
+	fake := fs.Bool("fake", false, "crawl the fakeFetcher of the tour instead of a local web server serving its pages")
+	if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errors.New("usage: " + crawlUsage)
+	}
+
+	var fetcher crawler.Fetcher = crawler.HTTPFetcher{}
+	url := fs.Arg(0)
+	switch {
+	case *fake:
+		fetcher = crawler.TourFetcher
+		if url == "" {
+			url = "https://golang.org/"
+		}
+	case url == "":
+		// Without a URL, crawl the pages of the tour served locally.
+		server := crawler.Serve(crawler.TourFetcher)
+		defer server.Close()
+		url = server.URL + "/"
+	}
+
+	for _, r := range crawler.Crawl(url, *depth, fetcher) {
+		if r.Err != nil {
+			fmt.Fprintln(os.Stderr, r.Err)
+			continue
+		}
+		fmt.Printf("%d found: %s %q\n", r.Depth, r.URL, r.Body)
+	}
+	return nil
+}
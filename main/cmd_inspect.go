@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"golearning/inspect"
+)
+
+const inspectUsage = "golearning inspect [--buf n] [--dump] [--xxd] <file>|-"
+
+// inspectFile logs the Read calls made to read a file, or stdin.
+func inspectFile(args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	size := fs.Int("buf", 16, "read with a buffer of `n` bytes")
+	dump := fs.Bool("dump", false, "dump the bytes returned by each Read")
+	xxd := fs.Bool("xxd", false, "only dump the bytes of the file, like xxd")
+	// Flags are accepted both before and after the file.
+	err := fs.Parse(args)
+	name := fs.Arg(0)
+	if err == nil {
+		err = fs.Parse(fs.Args()[min(1, fs.NArg()):])
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if name == "" || fs.NArg() != 0 {
+		return errors.New("usage: " + inspectUsage)
+	}
+	if *size <= 0 {
+		return fmt.Errorf("invalid --buf %d", *size)
+	}
+
+	f := os.Stdin
+	if name != "-" {
+		if f, err = os.Open(name); err != nil {
+			return err
+		}
+		defer f.Close()
+	}
+	if *xxd {
+		d := inspect.Dumper(os.Stdout)
+		if _, err := io.Copy(d, f); err != nil {
+			return err
+		}
+		return d.Close()
+	}
+
+	r := &inspect.Reader{R: f, Log: os.Stdout, Dump: *dump}
+	buf := make([]byte, *size)
+	for {
+		// Like io.ReadAll, read until an error, EOF included.
+		_, err := r.Read(buf)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%d bytes in %d calls\n", r.Offset(), r.Calls())
+	return nil
+}
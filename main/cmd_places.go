@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golearning/geo"
+	"golearning/spatial"
+)
+
+const placesUsage = "golearning places [--in file.geojson|file.csv] [--near lat,long [--k n] [--within km]] [--geojson]"
+
+// The places of the maps lesson, without --in.
+var lessonPlaces = map[string]Vertex2{
+	"Bell Labs": {40.68433, -74.39967},
+	"Google":    {37.42202, -122.08408},
+}
+
+func listPlaces(args []string) error {
+	fs := flag.NewFlagSet("places", flag.ContinueOnError)
+	in := fs.String("in", "", "read the places from the Point features of a GeoJSON `file`, named by their \"name\" property, or from the name,lat,long records of a .csv file")
+	near := fs.String("near", "", "sort the places by their distance to `lat,long`")
+	k := fs.Int("k", 0, "list only the `n` places nearest to --near")
+	within := fs.Float64("within", 0, "list only the places within `km` of --near")
+	export := fs.Bool("geojson", false, "write the places as GeoJSON")
+	if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New("usage: " + placesUsage)
+	}
+
+	places := geoPoints(lessonPlaces)
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		// Keep the valid places, and report the others.
+		if strings.EqualFold(filepath.Ext(*in), ".csv") {
+			places, err = geo.ReadCSV(f)
+		} else {
+			places, err = geo.ReadGeoJSON(f)
+		}
+		if err != nil && len(places) == 0 {
+			return err
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	if *export {
+		return geo.WriteGeoJSON(os.Stdout, places)
+	}
+
+	if *near == "" {
+		names := make([]string, 0, len(places))
+		for name := range places {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("%-20s %10.5f %11.5f\n", name, places[name].Lat, places[name].Long)
+		}
+		return nil
+	}
+	p, err := geo.ParsePoint(*near)
+	if err != nil {
+		return err
+	}
+	// Index the places only for the queries not listing them all.
+	var nearby []geo.Place
+	switch {
+	case *within > 0:
+		nearby = spatial.NewKDTree(places).Within(p, *within*1000)
+		if *k > 0 {
+			nearby = nearby[:min(*k, len(nearby))]
+		}
+	case *k > 0:
+		nearby = spatial.NewKDTree(places).Nearest(p, *k)
+	default:
+		nearby = geo.ByDistance(places, p)
+	}
+	for _, place := range nearby {
+		d, err := geo.Vincenty(p, place.Point)
+		if err != nil {
+			d = place.Distance
+		}
+		fmt.Printf("%-20s %10.5f %11.5f %10.1f km %5.1f°\n", place.Name, place.Point.Lat, place.Point.Long, d/1000, geo.Bearing(p, place.Point))
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golearning/gallery"
+	"golearning/render"
+)
+
+// renderSources are the pictures the render command draws, at a size
+// and with a colormap, nil for their own colors.
+var renderSources = map[string]func(w, h int, cm render.Colormap) image.Image{
+	"Pic": func(w, h int, cm render.Colormap) image.Image {
+		return render.FromPic(Pic, w, h, cm)
+	},
+	"Image": func(w, h int, cm render.Colormap) image.Image {
+		m := Image{Width: w, Height: h}
+		if cm != nil {
+			return render.Recolor(m, cm)
+		}
+		return m
+	},
+}
+
+func init() {
+	for _, g := range gallery.Generators {
+		g := g
+		renderSources[g.Name] = func(w, h int, cm render.Colormap) image.Image {
+			return g.New(gallery.Canvas{Width: w, Height: h, Colormap: cm})
+		}
+	}
+	// The contact sheet of the gallery, each picture at the size.
+	renderSources["gallery"] = func(w, h int, cm render.Colormap) image.Image {
+		var images []image.Image
+		for _, g := range gallery.Generators {
+			images = append(images, g.New(gallery.Canvas{Width: w, Height: h, Colormap: cm}))
+		}
+		return gallery.ContactSheet(images, 4, 8)
+	}
+}
+
+func sourceNames() []string {
+	names := make([]string, 0, len(renderSources))
+	for name := range renderSources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseSize parses a size written like 256x128.
+func parseSize(s string) (w, h int, err error) {
+	ws, hs, ok := strings.Cut(s, "x")
+	if ok {
+		w, err = strconv.Atoi(ws)
+	}
+	if ok && err == nil {
+		h, err = strconv.Atoi(hs)
+	}
+	if !ok || err != nil || w <= 0 || h <= 0 {
+		return 0, 0, fmt.Errorf("invalid size %q, want WIDTHxHEIGHT", s)
+	}
+	return w, h, nil
+}
+
+const renderUsage = "golearning render [--size WxH] [--colormap name] [--format png|gif|jpeg] [--o file] <picture>"
+
+func renderPicture(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	size := fs.String("size", "256x256", "size of the image, `WxH`")
+	colormap := fs.String("colormap", "", "color the levels with `name`: "+strings.Join(render.ColormapNames(), ", "))
+	format := fs.String("format", "", "image `format`: "+strings.Join(render.Formats, ", ")+" (default from the extension of --o, or png)")
+	output := fs.String("o", "", "write to `file` (default <picture>.<format>)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage:", renderUsage)
+		fmt.Fprintln(fs.Output(), "pictures:", strings.Join(sourceNames(), ", "))
+		fs.PrintDefaults()
+	}
+	// Flags are accepted both before and after the picture.
+	err := fs.Parse(args)
+	name := fs.Arg(0)
+	if err == nil {
+		err = fs.Parse(fs.Args()[min(1, fs.NArg()):])
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if name == "" || fs.NArg() != 0 {
+		return errors.New("usage: " + renderUsage)
+	}
+	source, ok := renderSources[name]
+	if !ok {
+		return fmt.Errorf("unknown picture %q, want one of %s", name, strings.Join(sourceNames(), ", "))
+	}
+	w, h, err := parseSize(*size)
+	if err != nil {
+		return err
+	}
+	var cm render.Colormap
+	if *colormap != "" {
+		if cm, ok = render.Colormaps[*colormap]; !ok {
+			return fmt.Errorf("unknown colormap %q", *colormap)
+		}
+	}
+	switch {
+	case *format == "" && *output != "":
+		if *format, err = render.FormatOf(*output); err != nil {
+			return err
+		}
+	case *format == "":
+		*format = "png"
+	case *output != "":
+		// An extension of another format would mislead the
+		// readers of the file.
+		if ext, err := render.FormatOf(*output); err == nil && ext != *format {
+			return fmt.Errorf("--format %s but --o %s is a %s file", *format, *output, ext)
+		}
+	}
+	if *output == "" {
+		*output = strings.ToLower(name) + "." + *format
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	// The pictures compute each pixel on demand: compute them
+	// in parallel before encoding.
+	m := render.Tiled(source(w, h, cm), 64, 0)
+	err = render.Encode(f, m, *format)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		// Leave no partial image behind.
+		os.Remove(*output)
+		return err
+	}
+	fmt.Println("wrote", *output)
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golearning/tree"
+)
+
+// treeFormats are the outputs of the tree command.
+var treeFormats = map[string]func(w io.Writer, t *tree.Tree) error{
+	"ascii": tree.Draw,
+	"dot":   tree.WriteDOT,
+	"svg":   tree.WriteSVG,
+	"levels": func(w io.Writer, t *tree.Tree) error {
+		_, err := io.WriteString(w, tree.FormatLevels(t))
+		return err
+	},
+	"json": func(w io.Writer, t *tree.Tree) error {
+		data, err := tree.FormatJSON(t)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	},
+}
+
+// readTree reads a tree written in the levels or the JSON
+// notation from the file name, or from stdin if name is "-".
+func readTree(name string) (*tree.Tree, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return tree.ParseJSON(trimmed)
+	}
+	return tree.ParseLevels(string(data))
+}
+
+const treeUsage = "golearning tree [--k n] [--seed n] [--in file] [--format ascii|dot|svg|levels|json] [--o file]"
+
+func drawTree(args []string) error {
+	fs := flag.NewFlagSet("tree", flag.ContinueOnError)
+	k := fs.Int("k", 0, "draw a random tree holding k, 2k, ..., 10k instead of the test tree")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "seed of the random tree")
+	input := fs.String("in", "", "read the tree from `file` (- for stdin), in the levels or the JSON notation")
+	format := fs.String("format", "ascii", "output `format`: ascii, dot, svg, levels or json")
+	output := fs.String("o", "", "write to `file` instead of the standard output")
+	if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New("usage: " + treeUsage)
+	}
+	write, ok := treeFormats[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	t := testTree()
+	switch {
+	case *input != "":
+		var err error
+		if t, err = readTree(*input); err != nil {
+			return err
+		}
+	case *k != 0:
+		t = newTree(newRandom(*seed), *k)
+	}
+	if *output == "" {
+		return write(os.Stdout, t)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := write(f, t); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,110 @@
+// Package crawler solves the Web Crawler exercise of the tour:
+// fetching the pages linked from a URL in parallel, without
+// fetching the same URL twice.
+package crawler
+
+import (
+	"sort"
+	"sync"
+)
+
+// A Fetcher returns the body of a page and the URLs it links to.
+type Fetcher interface {
+	Fetch(url string) (body string, urls []string, err error)
+}
+
+// A Result is a page fetched by Crawl.
+type Result struct {
+	URL   string
+	Depth int // number of links followed from the first URL
+	Body  string
+	Err   error
+}
+
+// Cache is the set of URLs already met by a crawl. It is safe
+// for concurrent use, like the SafeCounter of the tour.
+// The zero value is an empty cache.
+type Cache struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// Visit marks url as met, and reports whether it was the
+// first time: only the caller getting true should fetch url.
+func (c *Cache) Visit(url string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen[url] {
+		return false
+	}
+	if c.seen == nil {
+		c.seen = make(map[string]bool)
+	}
+	c.seen[url] = true
+	return true
+}
+
+// Len returns the number of URLs met.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.seen)
+}
+
+// MaxFetches is the number of pages Crawl fetches at once.
+const MaxFetches = 8
+
+// Crawl fetches url, then the pages it links to, and so on
+// up to depth pages deep: a depth of 1 only fetches url.
+//
+// The pages of a level are fetched in parallel, one goroutine
+// each, MaxFetches at most at once, and the next level starts
+// when they are all done.
+// Going level by level makes Crawl fetch each page by its
+// shortest path, so the same pages are fetched on every run.
+// No URL is fetched twice.
+//
+// The results are sorted by depth, then by URL.
+func Crawl(url string, depth int, fetcher Fetcher) []Result {
+	var (
+		cache   Cache
+		mu      sync.Mutex // guards results and next
+		results []Result
+		// A goroutine holds a slot of sem while it fetches.
+		sem = make(chan struct{}, MaxFetches)
+	)
+	cache.Visit(url)
+	level := []string{url}
+	for d := 0; d < depth && len(level) > 0; d++ {
+		var next []string
+		var wg sync.WaitGroup
+		for _, u := range level {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(u string) {
+				defer wg.Done()
+				body, urls, err := fetcher.Fetch(u)
+				<-sem
+				var found []string
+				for _, link := range urls {
+					if cache.Visit(link) {
+						found = append(found, link)
+					}
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				results = append(results, Result{URL: u, Depth: d, Body: body, Err: err})
+				next = append(next, found...)
+			}(u)
+		}
+		wg.Wait()
+		level = next
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Depth != results[j].Depth {
+			return results[i].Depth < results[j].Depth
+		}
+		return results[i].URL < results[j].URL
+	})
+	return results
+}
@@ -0,0 +1,226 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingFetcher counts the fetches of each URL.
+type countingFetcher struct {
+	Fetcher
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (f *countingFetcher) Fetch(url string) (string, []string, error) {
+	f.mu.Lock()
+	f.counts[url]++
+	f.mu.Unlock()
+	return f.Fetcher.Fetch(url)
+}
+
+func urls(results []Result) []string {
+	var s []string
+	for _, r := range results {
+		s = append(s, fmt.Sprintf("%d %s", r.Depth, r.URL))
+	}
+	return s
+}
+
+func TestCrawl(t *testing.T) {
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{0, nil},
+		{1, []string{"0 https://golang.org/"}},
+		{2, []string{
+			"0 https://golang.org/",
+			"1 https://golang.org/cmd/",
+			"1 https://golang.org/pkg/",
+		}},
+		{4, []string{
+			"0 https://golang.org/",
+			"1 https://golang.org/cmd/",
+			"1 https://golang.org/pkg/",
+			"2 https://golang.org/pkg/fmt/",
+			"2 https://golang.org/pkg/os/",
+		}},
+	}
+	for _, tt := range tests {
+		f := &countingFetcher{Fetcher: TourFetcher, counts: make(map[string]int)}
+		results := Crawl("https://golang.org/", tt.depth, f)
+		if got := urls(results); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Crawl(depth %d) = %q, want %q", tt.depth, got, tt.want)
+		}
+		for url, n := range f.counts {
+			if n != 1 {
+				t.Errorf("Crawl(depth %d) fetched %s %d times", tt.depth, url, n)
+			}
+		}
+		for _, r := range results {
+			if (r.Err != nil) != (r.URL == "https://golang.org/cmd/") {
+				t.Errorf("Crawl(depth %d): %s: error %v", tt.depth, r.URL, r.Err)
+			}
+		}
+	}
+}
+
+// TestCrawlWide crawls pages that all link to each other, so that
+// many goroutines meet the same URLs at once. Run it with -race.
+func TestCrawlWide(t *testing.T) {
+	const n = 50
+	f := FakeFetcher{}
+	var all []string
+	for i := 0; i < n; i++ {
+		all = append(all, fmt.Sprintf("https://example.com/%d", i))
+	}
+	for _, url := range all {
+		f[url] = &FakeResult{url, all}
+	}
+	cf := &countingFetcher{Fetcher: f, counts: make(map[string]int)}
+	results := Crawl(all[0], 3, cf)
+	if len(results) != n {
+		t.Errorf("Crawl returned %d results, want %d", len(results), n)
+	}
+	if len(cf.counts) != n {
+		t.Errorf("Crawl fetched %d URLs, want %d", len(cf.counts), n)
+	}
+	for url, c := range cf.counts {
+		if c != 1 {
+			t.Errorf("Crawl fetched %s %d times", url, c)
+		}
+	}
+}
+
+// parallelFetcher records the largest number of fetches at once.
+type parallelFetcher struct {
+	Fetcher
+	mu       sync.Mutex
+	running  int
+	greatest int
+}
+
+func (f *parallelFetcher) Fetch(url string) (string, []string, error) {
+	f.mu.Lock()
+	f.running++
+	f.greatest = max(f.greatest, f.running)
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.running--
+		f.mu.Unlock()
+	}()
+	time.Sleep(time.Millisecond)
+	return f.Fetcher.Fetch(url)
+}
+
+func TestCrawlMaxFetches(t *testing.T) {
+	const n = 100
+	f := FakeFetcher{}
+	var all []string
+	for i := 0; i < n; i++ {
+		all = append(all, fmt.Sprintf("https://example.com/%d", i))
+	}
+	for _, url := range all {
+		f[url] = &FakeResult{url, all}
+	}
+	pf := &parallelFetcher{Fetcher: f}
+	if results := Crawl(all[0], 2, pf); len(results) != n {
+		t.Errorf("Crawl returned %d results, want %d", len(results), n)
+	}
+	if pf.greatest > MaxFetches {
+		t.Errorf("Crawl fetched %d pages at once, want at most %d", pf.greatest, MaxFetches)
+	}
+}
+
+func TestHTTPFetcherDefaultClient(t *testing.T) {
+	if c := (HTTPFetcher{}).client(); c.Timeout != DefaultTimeout {
+		t.Errorf("default client timeout = %v, want %v", c.Timeout, DefaultTimeout)
+	}
+}
+
+func TestCache(t *testing.T) {
+	var c Cache
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	first := 0
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if c.Visit(fmt.Sprint(i % 10)) {
+				mu.Lock()
+				first++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if first != 10 || c.Len() != 10 {
+		t.Errorf("Visit returned true %d times for %d URLs, want 10", first, c.Len())
+	}
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	server := Serve(TourFetcher)
+	defer server.Close()
+	f := HTTPFetcher{Client: server.Client()}
+
+	body, links, err := f.Fetch(server.URL + "/pkg/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "Packages" {
+		t.Errorf("Fetch body = %q, want %q", body, "Packages")
+	}
+	var paths []string
+	for _, link := range links {
+		paths = append(paths, strings.TrimPrefix(link, server.URL))
+	}
+	want := []string{"/", "/cmd/", "/pkg/fmt/", "/pkg/os/"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Fetch links = %q, want %q", paths, want)
+	}
+
+	if _, _, err := f.Fetch(server.URL + "/cmd/"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Fetch(/cmd/) error = %v, want a 404", err)
+	}
+
+	results := Crawl(server.URL+"/", 4, f)
+	if got := len(results); got != 5 {
+		t.Errorf("Crawl over HTTP returned %d results, want 5", got)
+	}
+}
+
+func TestHTTPFetcherLinks(t *testing.T) {
+	page := `<html><head><TITLE> Links &amp; more </TITLE></head><body>
+<a href="a.html#top">relative</a>
+<a class="x" href='/b'>absolute path</a>
+<a href="https://other.example/c">other host</a>
+<a href="mailto:someone@example.com">mail</a>
+<a href="a.html">same again</a>
+</body></html>`
+	server := Serve(FakeFetcher{})
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	})
+	defer server.Close()
+
+	body, links, err := HTTPFetcher{Client: server.Client()}.Fetch(server.URL + "/dir/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "Links & more" {
+		t.Errorf("Fetch body = %q, want %q", body, "Links & more")
+	}
+	want := []string{server.URL + "/dir/a.html", server.URL + "/b", "https://other.example/c"}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("Fetch links = %q, want %q", links, want)
+	}
+}
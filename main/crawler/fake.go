@@ -0,0 +1,92 @@
+package crawler
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+)
+
+// FakeFetcher is a Fetcher returning canned results.
+type FakeFetcher map[string]*FakeResult
+
+// A FakeResult is a page of a FakeFetcher.
+type FakeResult struct {
+	Body string
+	URLs []string
+}
+
+func (f FakeFetcher) Fetch(url string) (string, []string, error) {
+	if res, ok := f[url]; ok {
+		return res.Body, res.URLs, nil
+	}
+	return "", nil, fmt.Errorf("not found: %s", url)
+}
+
+// TourFetcher holds the pages of the fakeFetcher of the tour.
+var TourFetcher = FakeFetcher{
+	"https://golang.org/": &FakeResult{
+		"The Go Programming Language",
+		[]string{
+			"https://golang.org/pkg/",
+			"https://golang.org/cmd/",
+		},
+	},
+	"https://golang.org/pkg/": &FakeResult{
+		"Packages",
+		[]string{
+			"https://golang.org/",
+			"https://golang.org/cmd/",
+			"https://golang.org/pkg/fmt/",
+			"https://golang.org/pkg/os/",
+		},
+	},
+	"https://golang.org/pkg/fmt/": &FakeResult{
+		"Package fmt",
+		[]string{
+			"https://golang.org/",
+			"https://golang.org/pkg/",
+		},
+	},
+	"https://golang.org/pkg/os/": &FakeResult{
+		"Package os",
+		[]string{
+			"https://golang.org/",
+			"https://golang.org/pkg/",
+		},
+	},
+}
+
+// Serve starts a local web server serving the pages of f as
+// HTML, each page at the path of its URL whatever its host.
+// The body of a page is its title, and its links are relative
+// so that they lead to the server too. The caller must Close
+// the server.
+func Serve(f FakeFetcher) *httptest.Server {
+	pages := make(map[string]*FakeResult)
+	for u, res := range f {
+		if parsed, err := url.Parse(u); err == nil {
+			pages[parsed.Path] = res
+		}
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "<html><head><title>%s</title></head><body>\n", html.EscapeString(res.Body))
+		for _, link := range res.URLs {
+			if parsed, err := url.Parse(link); err == nil {
+				fmt.Fprintf(&b, "<a href=\"%s\">%s</a>\n", html.EscapeString(parsed.Path), html.EscapeString(link))
+			}
+		}
+		b.WriteString("</body></html>\n")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, b.String())
+	}))
+}
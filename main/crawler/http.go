@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// HTTPFetcher fetches pages over HTTP. The body of a page is
+// its title, and its URLs are the http and https links it has.
+type HTTPFetcher struct {
+	// Client is the client used for the requests, a client
+	// giving up after DefaultTimeout if nil.
+	Client *http.Client
+}
+
+// DefaultTimeout bounds the time of a request of an HTTPFetcher
+// without a Client, from the connection to the end of the body:
+// unlike http.DefaultClient, a crawl never waits forever for a page.
+const DefaultTimeout = 10 * time.Second
+
+var defaultClient = &http.Client{Timeout: DefaultTimeout}
+
+// maxPageSize bounds the part of a page that is read.
+const maxPageSize = 1 << 20
+
+var (
+	titleRegexp = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	hrefRegexp  = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=\s*["']([^"']*)["']`)
+)
+
+func (f HTTPFetcher) client() *http.Client {
+	if f.Client == nil {
+		return defaultClient
+	}
+	return f.Client
+}
+
+func (f HTTPFetcher) Fetch(page string) (string, []string, error) {
+	resp, err := f.client().Get(page)
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("%s: %s", page, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return "", nil, err
+	}
+
+	var title string
+	if m := titleRegexp.FindSubmatch(data); m != nil {
+		title = strings.TrimSpace(html.UnescapeString(string(m[1])))
+	}
+	base := resp.Request.URL
+	var urls []string
+	seen := make(map[string]bool)
+	for _, m := range hrefRegexp.FindAllSubmatch(data, -1) {
+		ref, err := url.Parse(html.UnescapeString(string(m[1])))
+		if err != nil {
+			continue
+		}
+		link := base.ResolveReference(ref)
+		link.Fragment = ""
+		if link.Scheme != "http" && link.Scheme != "https" {
+			continue
+		}
+		if s := link.String(); !seen[s] {
+			seen[s] = true
+			urls = append(urls, s)
+		}
+	}
+	return title, urls, nil
+}
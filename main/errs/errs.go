@@ -0,0 +1,159 @@
+// Package errs provides structured errors: an Error carries a code,
+// the operation that failed, when it failed, and the error it wraps,
+// and can capture the call stack and render itself as JSON. A List
+// aggregates several errors into one.
+//
+// Errors work with the errors package of the standard library:
+// errors.Is(err, code) reports whether an Error of the chain has the
+// code, and errors.As finds the errors wrapped by an Error or a List.
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// A Code classifies an error. Codes are errors themselves, so that
+// errors.Is(err, NotFound) reports whether err has the code NotFound.
+type Code string
+
+// The codes of the errors.
+const (
+	Unknown      Code = "unknown"
+	Invalid      Code = "invalid"
+	NotFound     Code = "not_found"
+	Exists       Code = "exists"
+	Unavailable  Code = "unavailable"
+	Canceled     Code = "canceled"
+	Internal     Code = "internal"
+	Unauthorized Code = "unauthorized"
+)
+
+func (c Code) Error() string {
+	return string(c)
+}
+
+// CodeOf returns the code of the first Error in the chain of err,
+// Unknown if there is none, and the empty code if err is nil.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if errors.As(err, &e) && e.Code != "" {
+		return e.Code
+	}
+	return Unknown
+}
+
+// Error is a structured error. Only the fields that are set
+// appear in its message: "at When, Op: What: Err".
+type Error struct {
+	Code Code
+	Op   string    // operation that failed, like a function name
+	When time.Time // time of the failure
+	What string    // description of the failure
+	Err  error     // underlying error
+
+	stack []uintptr
+}
+
+// New returns an Error with the given code and description.
+func New(code Code, what string) *Error {
+	return &Error{Code: code, What: what}
+}
+
+// Errorf returns an Error with the given code, and its description
+// formatted as with fmt.Sprintf. Use Wrap, not %w, to wrap an error.
+func Errorf(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, What: fmt.Sprintf(format, args...)}
+}
+
+// Wrap returns an Error wrapping err, with the given code and
+// operation. err should not be nil.
+func Wrap(err error, code Code, op string) *Error {
+	return &Error{Code: code, Op: op, Err: err}
+}
+
+// At sets the time of the failure, and returns e.
+func (e *Error) At(t time.Time) *Error {
+	e.When = t
+	return e
+}
+
+// WithStack captures the call stack of its caller into e, and
+// returns e. The stack is printed with the %+v verb, and is part
+// of the JSON rendering.
+func (e *Error) WithStack() *Error {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers and WithStack.
+	n := runtime.Callers(2, pcs)
+	e.stack = pcs[:n]
+	return e
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	if !e.When.IsZero() {
+		fmt.Fprintf(&b, "at %v, ", e.When)
+	}
+	sep := ""
+	for _, s := range []string{e.Op, e.What} {
+		if s != "" {
+			b.WriteString(sep + s)
+			sep = ": "
+		}
+	}
+	if e.Err != nil {
+		b.WriteString(sep + e.Err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the error wrapped by e.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the code of e.
+func (e *Error) Is(target error) bool {
+	code, ok := target.(Code)
+	return ok && code == e.Code
+}
+
+// Stack returns the call stack captured by WithStack,
+// innermost call first.
+func (e *Error) Stack() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+	var stack []runtime.Frame
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			return stack
+		}
+	}
+}
+
+// Format formats e like its message, except for the %+v verb,
+// which adds the code and the captured stack.
+func (e *Error) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "%s [%s]", e.Error(), e.Code)
+		for _, frame := range e.Stack() {
+			fmt.Fprintf(f, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	default:
+		io.WriteString(f, e.Error())
+	}
+}
@@ -0,0 +1,147 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestError(t *testing.T) {
+	when := time.Date(2024, 3, 9, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		err  *Error
+		want string
+	}{
+		{New(Internal, "it didn't work"), "it didn't work"},
+		{New(Internal, "it didn't work").At(when), "at 2024-03-09 10:00:00 +0000 UTC, it didn't work"},
+		{Errorf(Invalid, "bad number %d", 4), "bad number 4"},
+		{Wrap(io.EOF, Unavailable, "read"), "read: EOF"},
+		{&Error{Op: "open", What: "config", Err: fs.ErrNotExist}, "open: config: file does not exist"},
+		{&Error{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+		if got := fmt.Sprint(tt.err); got != tt.want {
+			t.Errorf("Sprint = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	inner := Wrap(fs.ErrNotExist, NotFound, "open")
+	err := fmt.Errorf("loading: %w", Wrap(inner, Unavailable, "load"))
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("errors.Is(err, fs.ErrNotExist) = false")
+	}
+	if !errors.Is(err, NotFound) || !errors.Is(err, Unavailable) {
+		t.Error("errors.Is does not find the codes of the chain")
+	}
+	if errors.Is(err, Invalid) {
+		t.Error("errors.Is(err, Invalid) = true")
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.Op != "load" {
+		t.Errorf("errors.As found %v, want the load error", e)
+	}
+	if got := CodeOf(err); got != Unavailable {
+		t.Errorf("CodeOf = %q, want %q", got, Unavailable)
+	}
+	if got := CodeOf(io.EOF); got != Unknown {
+		t.Errorf("CodeOf(io.EOF) = %q, want %q", got, Unknown)
+	}
+	if got := CodeOf(nil); got != "" {
+		t.Errorf("CodeOf(nil) = %q, want none", got)
+	}
+}
+
+func TestStack(t *testing.T) {
+	err := New(Internal, "boom")
+	if err.Stack() != nil {
+		t.Errorf("Stack() = %v without WithStack", err.Stack())
+	}
+	if s := fmt.Sprintf("%+v", err); s != "boom [internal]" {
+		t.Errorf("%%+v = %q", s)
+	}
+
+	err.WithStack()
+	stack := err.Stack()
+	if len(stack) == 0 || !strings.HasSuffix(stack[0].Function, ".TestStack") {
+		t.Fatalf("Stack() = %v, want TestStack first", stack)
+	}
+	s := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(s, "boom [internal]\n\t") || !strings.Contains(s, "errs_test.go:") {
+		t.Errorf("%%+v = %q, want the message then the stack", s)
+	}
+	if s := fmt.Sprintf("%v", err); s != "boom" {
+		t.Errorf("%%v = %q, want the message only", s)
+	}
+}
+
+func TestList(t *testing.T) {
+	var list List
+	if list.Err() != nil {
+		t.Error("empty List.Err() != nil")
+	}
+	list = list.Append(nil, io.EOF)
+	if list.Err() != io.EOF {
+		t.Errorf("List.Err() = %v, want the only error", list.Err())
+	}
+	list = list.Append(nil, List{New(NotFound, "a"), New(Invalid, "b")})
+	if len(list) != 3 {
+		t.Fatalf("Append did not flatten: %d errors", len(list))
+	}
+	err := list.Err()
+	if got, want := err.Error(), "EOF; a; b"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, io.EOF) || !errors.Is(err, Invalid) || errors.Is(err, Canceled) {
+		t.Error("errors.Is does not look into the list")
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.What != "a" {
+		t.Errorf("errors.As found %v, want the first Error", e)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	when := time.Date(2024, 3, 9, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, `null`},
+		{io.EOF, `{"message":"EOF"}`},
+		{New(Invalid, "bad").At(when), `{"code":"invalid","when":"2024-03-09T10:00:00Z","message":"bad"}`},
+		{Wrap(Wrap(io.EOF, Unavailable, "read"), Internal, "load"),
+			`{"code":"internal","op":"load","cause":{"code":"unavailable","op":"read","cause":{"message":"EOF"}}}`},
+		{List{io.EOF, New(NotFound, "x")},
+			`{"message":"EOF; x","errors":[{"message":"EOF"},{"code":"not_found","message":"x"}]}`},
+	}
+	for _, tt := range tests {
+		data, err := JSON(tt.err)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("JSON(%v) = %s, %v, want %s", tt.err, data, err, tt.want)
+		}
+		if tt.err == nil || tt.err == io.EOF {
+			continue
+		}
+		// json.Marshal uses the same rendering.
+		if data, _ := json.Marshal(tt.err); string(data) != tt.want {
+			t.Errorf("json.Marshal(%v) = %s, want %s", tt.err, data, tt.want)
+		}
+	}
+
+	data, _ := JSON(New(Internal, "boom").WithStack())
+	var j struct{ Stack []string }
+	if err := json.Unmarshal(data, &j); err != nil || len(j.Stack) == 0 || !strings.Contains(j.Stack[0], "TestJSON") {
+		t.Errorf("JSON stack = %q, %v", j.Stack, err)
+	}
+}
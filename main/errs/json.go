@@ -0,0 +1,57 @@
+package errs
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// jsonError is the JSON rendering of an error.
+type jsonError struct {
+	Code    Code       `json:"code,omitempty"`
+	Op      string     `json:"op,omitempty"`
+	When    *time.Time `json:"when,omitempty"`
+	Message string     `json:"message,omitempty"`
+	Cause   any        `json:"cause,omitempty"`
+	Errors  []any      `json:"errors,omitempty"`
+	Stack   []string   `json:"stack,omitempty"`
+}
+
+// render returns the JSON rendering of err: an Error is an object
+// with its fields and its cause, a List an object with its errors,
+// and any other error an object with its message.
+func render(err error) any {
+	switch err := err.(type) {
+	case nil:
+		return nil
+	case *Error:
+		j := jsonError{Code: err.Code, Op: err.Op, Message: err.What, Cause: render(err.Err)}
+		if !err.When.IsZero() {
+			j.When = &err.When
+		}
+		for _, frame := range err.Stack() {
+			j.Stack = append(j.Stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		return j
+	case List:
+		j := jsonError{Message: err.Error(), Errors: []any{}}
+		for _, e := range err {
+			j.Errors = append(j.Errors, render(e))
+		}
+		return j
+	}
+	return jsonError{Message: err.Error()}
+}
+
+// JSON returns the JSON rendering of err, with its chain of causes.
+func JSON(err error) ([]byte, error) {
+	return json.Marshal(render(err))
+}
+
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(render(e))
+}
+
+func (list List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(render(list))
+}
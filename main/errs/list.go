@@ -0,0 +1,49 @@
+package errs
+
+import "strings"
+
+// List is an error aggregating several errors, like the errors
+// found when validating the fields of a form. errors.Is and
+// errors.As look into each of them.
+type List []error
+
+// Append appends the non-nil errors to list, and returns it.
+// It flattens the Lists it is given.
+func (list List) Append(errs ...error) List {
+	for _, err := range errs {
+		switch err := err.(type) {
+		case nil:
+		case List:
+			list = list.Append(err...)
+		default:
+			list = append(list, err)
+		}
+	}
+	return list
+}
+
+// Err returns nil if the list is empty, the error it holds if it
+// has only one, and the list itself otherwise. Return list.Err()
+// rather than the list, which is never a nil error.
+func (list List) Err() error {
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	}
+	return list
+}
+
+func (list List) Error() string {
+	msgs := make([]string, len(list))
+	for i, err := range list {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of the list.
+func (list List) Unwrap() []error {
+	return list
+}
@@ -2,6 +2,14 @@ package main
 
 // import "golang.org/x/tour/pic"
 
+var slicesExercise = lesson{
+	name:  "slices-exercise",
+	title: "Exercise: Slices",
+	steps: []step{
+		{"Pic", showPic},
+	},
+}
+
 func Pic(dx, dy int) [][]uint8 {
 	slice := make([][]uint8, dy)
 	for i := 0; i < dy; i++ {
@@ -15,6 +23,9 @@ func Pic(dx, dy int) [][]uint8 {
 	return slice
 }
 
-func main() {
+func showPic(ctx *Context) {
 	// pic.Show(Pic)
+	for _, row := range Pic(8, 8) {
+		ctx.Println(row)
+	}
 }
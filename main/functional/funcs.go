@@ -0,0 +1,60 @@
+package functional
+
+import "sync"
+
+// Compose returns the function applying f, then g: g∘f.
+func Compose[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(x A) C { return g(f(x)) }
+}
+
+// Curry returns f taking its arguments one at a time: Curry(f)(x)(y)
+// is f(x, y), and Curry(f)(x) is f with its first argument bound.
+func Curry[A, B, R any](f func(A, B) R) func(A) func(B) R {
+	return func(x A) func(B) R {
+		return func(y B) R { return f(x, y) }
+	}
+}
+
+// Uncurry returns the function of two arguments of a curried f.
+func Uncurry[A, B, R any](f func(A) func(B) R) func(A, B) R {
+	return func(x A, y B) R { return f(x)(y) }
+}
+
+// Flip returns f taking its arguments in the other order.
+func Flip[A, B, R any](f func(A, B) R) func(B, A) R {
+	return func(y B, x A) R { return f(x, y) }
+}
+
+// Memoize returns f remembering its results: it computes f once per
+// argument, however many times it is called with it. The memoized
+// function may be called from several goroutines, and by f itself,
+// to memoize a recursive function:
+//
+//	var fib func(int) int
+//	fib = Memoize(func(n int) int {
+//		if n < 2 {
+//			return n
+//		}
+//		return fib(n-1) + fib(n-2)
+//	})
+//
+// Goroutines calling it at the same time with the same argument may
+// each compute f.
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	var mu sync.Mutex
+	cache := make(map[K]V)
+	return func(k K) V {
+		mu.Lock()
+		v, ok := cache[k]
+		mu.Unlock()
+		if ok {
+			return v
+		}
+		// Not holding the lock, for f to call the memoized function.
+		v = f(k)
+		mu.Lock()
+		cache[k] = v
+		mu.Unlock()
+		return v
+	}
+}
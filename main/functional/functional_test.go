@@ -0,0 +1,246 @@
+package functional
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSlices(t *testing.T) {
+	primes := []int{2, 3, 5, 7, 11, 13}
+	square := func(x int) int { return x * x }
+	odd := func(x int) bool { return x%2 == 1 }
+	add := func(a, x int) int { return a + x }
+
+	if got := Map(primes, square); !reflect.DeepEqual(got, []int{4, 9, 25, 49, 121, 169}) {
+		t.Errorf("Map(square) = %v", got)
+	}
+	if got := Map(primes, strconv.Itoa); !reflect.DeepEqual(got, []string{"2", "3", "5", "7", "11", "13"}) {
+		t.Errorf("Map(Itoa) = %v", got)
+	}
+	if got := Filter(primes, odd); !reflect.DeepEqual(got, []int{3, 5, 7, 11, 13}) {
+		t.Errorf("Filter(odd) = %v", got)
+	}
+	if got := Reduce(primes, 0, add); got != 41 {
+		t.Errorf("Reduce(add) = %v, want 41", got)
+	}
+	if got := Reduce(primes, "", func(s string, x int) string { return s + strconv.Itoa(x) }); got != "23571113" {
+		t.Errorf("Reduce(concat) = %q", got)
+	}
+	if !reflect.DeepEqual(primes, []int{2, 3, 5, 7, 11, 13}) {
+		t.Errorf("primes changed: %v", primes)
+	}
+
+	// Map, Filter and Reduce of nothing.
+	var none []int
+	if got := Map(none, square); len(got) != 0 {
+		t.Errorf("Map(nil) = %v", got)
+	}
+	if got := Filter(none, odd); got != nil {
+		t.Errorf("Filter(nil) = %v", got)
+	}
+	if got := Reduce(none, 42, add); got != 42 {
+		t.Errorf("Reduce(nil, 42) = %v", got)
+	}
+
+	// Filter keeps the type of the slice.
+	type primesT []int
+	var _ primesT = Filter(primesT(primes), odd)
+}
+
+func TestZip(t *testing.T) {
+	names := []string{"John", "Paul", "George", "Ringo"}
+	born := []int{1940, 1942, 1943}
+	pairs := Zip(names, born)
+	want := []Pair[string, int]{{"John", 1940}, {"Paul", 1942}, {"George", 1943}}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("Zip = %v, want %v", pairs, want)
+	}
+	a, b := Unzip(pairs)
+	if !reflect.DeepEqual(a, names[:3]) || !reflect.DeepEqual(b, born) {
+		t.Errorf("Unzip = %v, %v", a, b)
+	}
+	if got := ZipWith([]float64{3, 5}, []float64{4, 12}, math.Hypot); !reflect.DeepEqual(got, []float64{5, 13}) {
+		t.Errorf("ZipWith(Hypot) = %v", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	words := strings.Fields("go gopher tour map slice func closure struct")
+	groups := GroupBy(words, func(w string) int { return len(w) })
+	want := map[int][]string{2: {"go"}, 3: {"map"}, 4: {"tour", "func"}, 5: {"slice"}, 6: {"gopher", "struct"}, 7: {"closure"}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("GroupBy(len) = %v, want %v", groups, want)
+	}
+
+	short, long := Partition(words, func(w string) bool { return len(w) < 5 })
+	if !reflect.DeepEqual(short, []string{"go", "tour", "map", "func"}) || !reflect.DeepEqual(long, []string{"gopher", "slice", "closure", "struct"}) {
+		t.Errorf("Partition = %v, %v", short, long)
+	}
+}
+
+func TestMaps(t *testing.T) {
+	ages := map[string]int{"Rob": 67, "Ken": 81, "Robert": 60}
+	if got := Keys(ages); !reflect.DeepEqual(got, []string{"Ken", "Rob", "Robert"}) {
+		t.Errorf("Keys = %v", got)
+	}
+	if got := MapValues(ages, func(age int) bool { return age >= 65 }); !reflect.DeepEqual(got, map[string]bool{"Rob": true, "Ken": true, "Robert": false}) {
+		t.Errorf("MapValues = %v", got)
+	}
+	if got := FilterMap(ages, func(name string, _ int) bool { return strings.HasPrefix(name, "Rob") }); !reflect.DeepEqual(got, map[string]int{"Rob": 67, "Robert": 60}) {
+		t.Errorf("FilterMap = %v", got)
+	}
+	got := ReduceMap(ages, "", func(s, name string, age int) string { return s + name + strconv.Itoa(age) })
+	if got != "Ken81Rob67Robert60" {
+		t.Errorf("ReduceMap = %q", got)
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	hypot := func(x, y float64) float64 { return math.Sqrt(x*x + y*y) }
+	if got := Curry(hypot)(5)(12); got != 13 {
+		t.Errorf("Curry(hypot)(5)(12) = %v", got)
+	}
+	if got := Uncurry(Curry(math.Pow))(2, 10); got != 1024 {
+		t.Errorf("Uncurry(Curry(Pow))(2, 10) = %v", got)
+	}
+	if got := Flip(math.Pow)(2, 10); got != 100 {
+		t.Errorf("Flip(Pow)(2, 10) = %v", got)
+	}
+	double := func(x int) int { return 2 * x }
+	f := Compose(double, strconv.Itoa)
+	if got := f(21); got != "42" {
+		t.Errorf("Compose(double, Itoa)(21) = %q", got)
+	}
+	inc := func(x int) int { return x + 1 }
+	if got := Compose(inc, double)(1); got != 4 {
+		t.Errorf("Compose(inc, double)(1) = %v, want double(inc(1)) = 4", got)
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int64
+	var fib func(int) int
+	fib = Memoize(func(n int) int {
+		calls.Add(1)
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	if got := fib(90); got != 2880067194370816120 {
+		t.Errorf("fib(90) = %v", got)
+	}
+	if calls.Load() != 91 {
+		t.Errorf("fib(90) computed %d times, want 91", calls.Load())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n <= 90; n++ {
+				fib(n)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 91 {
+		t.Errorf("fib computed %d times, want 91", calls.Load())
+	}
+}
+
+var (
+	benchInts = make([]int, 100000)
+	sink      int // the results of the benchmarks, not to be optimized away
+)
+
+func init() {
+	for i := range benchInts {
+		benchInts[i] = i
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	square := func(x int) int { return x * x }
+	b.Run("Map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink += len(Map(benchInts, square))
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r := make([]int, len(benchInts))
+			for j, x := range benchInts {
+				r[j] = x * x
+			}
+			sink += len(r)
+		}
+	})
+}
+
+func BenchmarkFilter(b *testing.B) {
+	odd := func(x int) bool { return x%2 == 1 }
+	b.Run("Filter", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink += len(Filter(benchInts, odd))
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var r []int
+			for _, x := range benchInts {
+				if x%2 == 1 {
+					r = append(r, x)
+				}
+			}
+			sink += len(r)
+		}
+	})
+}
+
+func BenchmarkReduce(b *testing.B) {
+	add := func(a, x int) int { return a + x }
+	b.Run("Reduce", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink += Reduce(benchInts, 0, add)
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for _, x := range benchInts {
+				sum += x
+			}
+			sink += sum
+		}
+	})
+}
+
+// The sum of the squares of the odd numbers: three passes and two
+// slices, against one pass.
+func BenchmarkChain(b *testing.B) {
+	b.Run("Chain", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			odds := Filter(benchInts, func(x int) bool { return x%2 == 1 })
+			squares := Map(odds, func(x int) int { return x * x })
+			sink += Reduce(squares, 0, func(a, x int) int { return a + x })
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for _, x := range benchInts {
+				if x%2 == 1 {
+					sum += x * x
+				}
+			}
+			sink += sum
+		}
+	})
+}
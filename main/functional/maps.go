@@ -0,0 +1,48 @@
+package functional
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Keys returns the keys of m, sorted.
+func Keys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// MapValues returns the map of the keys of m to the results of f on
+// their values.
+func MapValues[M ~map[K]V, K comparable, V, R any](m M, f func(V) R) map[K]R {
+	r := make(map[K]R, len(m))
+	for k, v := range m {
+		r[k] = f(v)
+	}
+	return r
+}
+
+// FilterMap returns the entries of m for which keep is true.
+func FilterMap[M ~map[K]V, K comparable, V any](m M, keep func(K, V) bool) M {
+	r := make(M)
+	for k, v := range m {
+		if keep(k, v) {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// ReduceMap folds the entries of m into an accumulator, from init and
+// with f, in the order of the keys: the order of a range over a map
+// changes from one to the next.
+func ReduceMap[M ~map[K]V, K cmp.Ordered, V, A any](m M, init A, f func(A, K, V) A) A {
+	acc := init
+	for _, k := range Keys(m) {
+		acc = f(acc, k, m[k])
+	}
+	return acc
+}
@@ -0,0 +1,93 @@
+// Package functional is higher-order programming with type
+// parameters: functions taking functions as values, like compute in
+// the collections lesson, over slices and maps of any types, and
+// functions making functions, like the closures of the lesson.
+//
+// The functions over slices never change the slices they are given,
+// and return new ones.
+package functional
+
+// Map returns the results of f on the elements of s.
+func Map[S ~[]E, E, R any](s S, f func(E) R) []R {
+	r := make([]R, len(s))
+	for i, e := range s {
+		r[i] = f(e)
+	}
+	return r
+}
+
+// Filter returns the elements of s for which keep is true.
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	var r S
+	for _, e := range s {
+		if keep(e) {
+			r = append(r, e)
+		}
+	}
+	return r
+}
+
+// Reduce folds the elements of s into an accumulator, from init and
+// with f, from the first element to the last.
+func Reduce[S ~[]E, E, A any](s S, init A, f func(A, E) A) A {
+	acc := init
+	for _, e := range s {
+		acc = f(acc, e)
+	}
+	return acc
+}
+
+// Pair is a pair of values of any types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns the pairs of the elements of a and b of the same
+// index, as many as the shortest of them.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	return ZipWith(a, b, func(x A, y B) Pair[A, B] { return Pair[A, B]{x, y} })
+}
+
+// ZipWith returns the results of f on the elements of a and b of the
+// same index, as many as the shortest of them.
+func ZipWith[A, B, R any](a []A, b []B, f func(A, B) R) []R {
+	r := make([]R, min(len(a), len(b)))
+	for i := range r {
+		r[i] = f(a[i], b[i])
+	}
+	return r
+}
+
+// Unzip returns the first and the second values of the pairs.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	a, b := make([]A, len(pairs)), make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i], b[i] = p.First, p.Second
+	}
+	return a, b
+}
+
+// GroupBy returns the elements of s grouped by their key, in their
+// order in s.
+func GroupBy[S ~[]E, E any, K comparable](s S, key func(E) K) map[K]S {
+	groups := make(map[K]S)
+	for _, e := range s {
+		k := key(e)
+		groups[k] = append(groups[k], e)
+	}
+	return groups
+}
+
+// Partition returns the elements of s for which f is true, and
+// those for which it is false, in their order in s.
+func Partition[S ~[]E, E any](s S, f func(E) bool) (yes, no S) {
+	for _, e := range s {
+		if f(e) {
+			yes = append(yes, e)
+		} else {
+			no = append(no, e)
+		}
+	}
+	return yes, no
+}
@@ -0,0 +1,84 @@
+package gallery
+
+import (
+	"image/color"
+	"math"
+	"math/cmplx"
+)
+
+// View is the part of the complex plane a fractal shows: the point
+// at the center of the image, and the width of the image, the height
+// following the aspect ratio of the image.
+type View struct {
+	Center complex128
+	Width  float64
+}
+
+// point returns the point of the plane at the pixel (x, y) of c.
+func (v View) point(c Canvas, x, y int) complex128 {
+	scale := v.Width / float64(c.Width)
+	return v.Center + complex(
+		(float64(x)-float64(c.Width)/2)*scale,
+		(float64(c.Height)/2-float64(y))*scale)
+}
+
+// escape iterates z = z*z + c from z and returns the level of the
+// point, by the smoothed number of iterations it takes z to escape,
+// or 0 if it does not escape in maxIter iterations.
+func escape(z, c complex128, maxIter int) uint8 {
+	const radius = 256 // escaping further gives a smoother count
+	for n := 0; n < maxIter; n++ {
+		if real(z)*real(z)+imag(z)*imag(z) > radius*radius {
+			mu := float64(n) + 1 - math.Log2(math.Log(cmplx.Abs(z)))
+			return uint8(1 + 254*math.Sqrt(math.Max(0, mu)/float64(maxIter)))
+		}
+		z = z*z + c
+	}
+	return 0
+}
+
+// Mandelbrot is the Mandelbrot set: the points c for which
+// z = z*z + c, from z = 0, never escapes. Points of the set
+// are at level 0, the others at a level growing with the time
+// they take to escape.
+type Mandelbrot struct {
+	Canvas
+	View    View // the whole set if zero
+	MaxIter int  // 256 if zero
+}
+
+func (m Mandelbrot) At(x, y int) color.Color {
+	v := m.View
+	if v == (View{}) {
+		v = View{-0.5, 3}
+	}
+	return m.Color(escape(0, v.point(m.Canvas, x, y), maxIter(m.MaxIter)))
+}
+
+// Julia is the Julia set of C: the points z for which z = z*z + C
+// never escapes.
+type Julia struct {
+	Canvas
+	C       complex128 // -0.8+0.156i if zero
+	View    View       // centered on 0 if zero
+	MaxIter int        // 256 if zero
+}
+
+func (m Julia) At(x, y int) color.Color {
+	v := m.View
+	if v == (View{}) {
+		v = View{0, 3.2}
+	}
+	c := m.C
+	if c == 0 {
+		c = -0.8 + 0.156i
+	}
+	return m.Color(escape(v.point(m.Canvas, x, y), c, maxIter(m.MaxIter)))
+}
+
+func maxIter(n int) int {
+	if n <= 0 {
+		return 256
+	}
+	return n
+}
@@ -0,0 +1,124 @@
+// Package gallery holds procedural pictures, each an image.Image
+// like the Image of the images exercise: the patterns suggested by
+// the slices exercise, fractals, noise, plasma and checkerboards.
+// Each computes a level, from 0 to 255, for every pixel, colored by
+// a colormap of the render package.
+package gallery
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"golearning/render"
+)
+
+// Canvas is the part shared by the pictures: their size, and the
+// colormap of their levels, render.Tour if nil.
+type Canvas struct {
+	Width, Height int
+	Colormap      render.Colormap
+}
+
+func (c Canvas) Bounds() image.Rectangle {
+	return image.Rect(0, 0, c.Width, c.Height)
+}
+
+func (c Canvas) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Color returns the color of the level v.
+func (c Canvas) Color(v uint8) color.Color {
+	if c.Colormap == nil {
+		return render.Tour(v)
+	}
+	return c.Colormap(v)
+}
+
+// Average is the picture of (x+y)/2, the one of Image.
+type Average struct{ Canvas }
+
+func (m Average) At(x, y int) color.Color { return m.Color(uint8((x + y) / 2)) }
+
+// Xor is the picture of x^y.
+type Xor struct{ Canvas }
+
+func (m Xor) At(x, y int) color.Color { return m.Color(uint8(x ^ y)) }
+
+// Product is the picture of x*y, the one of Pic.
+type Product struct{ Canvas }
+
+func (m Product) At(x, y int) color.Color { return m.Color(uint8(x * y)) }
+
+// Checkerboard alternates squares of Size pixels, 32 if zero.
+type Checkerboard struct {
+	Canvas
+	Size int
+}
+
+func (m Checkerboard) At(x, y int) color.Color {
+	size := m.Size
+	if size <= 0 {
+		size = 32
+	}
+	if (x/size+y/size)%2 == 0 {
+		return m.Color(255)
+	}
+	return m.Color(0)
+}
+
+// A Generator makes a picture on a canvas.
+type Generator struct {
+	Name string
+	New  func(c Canvas) image.Image
+}
+
+// Generators are the pictures of the gallery, with their
+// default parameters.
+var Generators = []Generator{
+	{"average", func(c Canvas) image.Image { return Average{c} }},
+	{"xor", func(c Canvas) image.Image { return Xor{c} }},
+	{"product", func(c Canvas) image.Image { return Product{c} }},
+	{"checkerboard", func(c Canvas) image.Image { return Checkerboard{Canvas: c} }},
+	{"mandelbrot", func(c Canvas) image.Image { return Mandelbrot{Canvas: c} }},
+	{"julia", func(c Canvas) image.Image { return Julia{Canvas: c} }},
+	{"perlin", func(c Canvas) image.Image { return NewPerlin(c, 1) }},
+	{"plasma", func(c Canvas) image.Image { return Plasma{Canvas: c} }},
+}
+
+// Lookup returns the generator called name, in any case.
+func Lookup(name string) (Generator, bool) {
+	for _, g := range Generators {
+		if strings.EqualFold(g.Name, name) {
+			return g, true
+		}
+	}
+	return Generator{}, false
+}
+
+// ContactSheet returns the images laid out on a grid of cols
+// columns, cells being as large as the largest image and apart
+// by gap pixels of white.
+func ContactSheet(images []image.Image, cols, gap int) *image.RGBA {
+	if len(images) == 0 || cols <= 0 {
+		return image.NewRGBA(image.Rectangle{})
+	}
+	var cell image.Point
+	for _, m := range images {
+		size := m.Bounds().Size()
+		cell.X = max(cell.X, size.X)
+		cell.Y = max(cell.Y, size.Y)
+	}
+	cols = min(cols, len(images))
+	rows := (len(images) + cols - 1) / cols
+	sheet := image.NewRGBA(image.Rect(0, 0,
+		cols*(cell.X+gap)+gap, rows*(cell.Y+gap)+gap))
+	draw.Draw(sheet, sheet.Bounds(), image.White, image.Point{}, draw.Src)
+	for i, m := range images {
+		at := image.Pt(gap+i%cols*(cell.X+gap), gap+i/cols*(cell.Y+gap))
+		draw.Draw(sheet, m.Bounds().Sub(m.Bounds().Min).Add(at), m, m.Bounds().Min, draw.Src)
+	}
+	return sheet
+}
@@ -0,0 +1,135 @@
+package gallery
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golearning/render"
+)
+
+var canvas = Canvas{Width: 64, Height: 48}
+
+func TestGenerators(t *testing.T) {
+	for _, g := range Generators {
+		m := g.New(canvas)
+		if m.Bounds() != image.Rect(0, 0, 64, 48) {
+			t.Errorf("%s: Bounds() = %v", g.Name, m.Bounds())
+		}
+		// Every picture has more than one color.
+		colors := make(map[color.Color]bool)
+		for y := 0; y < 48; y += 3 {
+			for x := 0; x < 64; x += 3 {
+				colors[m.At(x, y)] = true
+			}
+		}
+		if len(colors) < 2 {
+			t.Errorf("%s: a single color", g.Name)
+		}
+		if got, ok := Lookup(g.Name); !ok || got.Name != g.Name {
+			t.Errorf("Lookup(%q) = %v, %v", g.Name, got.Name, ok)
+		}
+	}
+	if _, ok := Lookup("nope"); ok {
+		t.Error(`Lookup("nope") found a generator`)
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	gray := Canvas{Width: 256, Height: 256, Colormap: render.Gray}
+	tests := []struct {
+		m    image.Image
+		x, y int
+		want uint8
+	}{
+		{Average{canvas}, 10, 20, 15},
+		{Xor{gray}, 12, 10, 6},
+		{Product{gray}, 20, 13, 4},
+		{Checkerboard{Canvas: gray}, 40, 0, 0},
+		{Checkerboard{Canvas: gray, Size: 8}, 48, 0, 255},
+	}
+	for _, tt := range tests {
+		var want color.Color = render.Gray(tt.want)
+		if _, ok := tt.m.(Average); ok {
+			want = render.Tour(tt.want)
+		}
+		if got := tt.m.At(tt.x, tt.y); got != want {
+			t.Errorf("%T.At(%d, %d) = %v, want %v", tt.m, tt.x, tt.y, got, want)
+		}
+	}
+}
+
+func level(m image.Image, x, y int) uint8 {
+	return color.GrayModel.Convert(m.At(x, y)).(color.Gray).Y
+}
+
+func TestFractals(t *testing.T) {
+	gray := Canvas{Width: 64, Height: 48, Colormap: render.Gray}
+	m := Mandelbrot{Canvas: gray}
+	// The center, -0.5, is in the set, the corners are not.
+	if level(m, 32, 24) != 0 || level(m, 0, 0) == 0 {
+		t.Errorf("Mandelbrot levels: center %d, corner %d", level(m, 32, 24), level(m, 0, 0))
+	}
+	// Zooming on a point outside the set shows no point of it.
+	zoom := Mandelbrot{Canvas: gray, View: View{Center: 1 + 1i, Width: 0.1}}
+	if level(zoom, 32, 24) == 0 {
+		t.Error("Mandelbrot zoomed on 1+1i shows the set")
+	}
+	j := Julia{Canvas: gray, C: -1}
+	// 0 is in the Julia set of -1: 0, -1, 0, -1...
+	if level(j, 32, 24) != 0 || level(j, 0, 0) == 0 {
+		t.Errorf("Julia levels: center %d, corner %d", level(j, 32, 24), level(j, 0, 0))
+	}
+}
+
+func TestPerlin(t *testing.T) {
+	a, b, c := NewPerlin(canvas, 1), NewPerlin(canvas, 1), NewPerlin(canvas, 2)
+	same, differ := true, false
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			same = same && a.At(x, y) == b.At(x, y)
+			differ = differ || a.At(x, y) != c.At(x, y)
+		}
+	}
+	if !same || !differ {
+		t.Errorf("Perlin noise: same for the same seed %v, differs for another seed %v", same, differ)
+	}
+	// The noise is continuous: neighbors have close levels.
+	gray := NewPerlin(Canvas{Width: 64, Height: 64, Colormap: render.Gray}, 1)
+	for x := 1; x < 64; x++ {
+		if d := int(level(gray, x, 10)) - int(level(gray, x-1, 10)); d > 32 || d < -32 {
+			t.Errorf("Perlin jumps by %d between x = %d and %d", d, x-1, x)
+		}
+	}
+}
+
+func TestPlasmaPhase(t *testing.T) {
+	if (Plasma{Canvas: canvas}).At(10, 10) == (Plasma{Canvas: canvas, Phase: 1}).At(10, 10) {
+		t.Error("the phase does not change the plasma")
+	}
+}
+
+func TestContactSheet(t *testing.T) {
+	images := []image.Image{
+		Average{Canvas{Width: 10, Height: 10}},
+		Xor{Canvas{Width: 20, Height: 5}},
+		Product{Canvas{Width: 10, Height: 10}},
+	}
+	sheet := ContactSheet(images, 2, 2)
+	// 2 columns of 20 pixels, 2 rows of 10, 2 pixels apart.
+	if sheet.Bounds() != image.Rect(0, 0, 46, 26) {
+		t.Fatalf("Bounds() = %v", sheet.Bounds())
+	}
+	if sheet.At(0, 0) != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("gap color %v, want white", sheet.At(0, 0))
+	}
+	if got, want := sheet.At(2+22+3, 2+4), images[1].At(3, 4); got != want {
+		t.Errorf("second image At(3, 4) = %v, want %v", got, want)
+	}
+	if got, want := sheet.At(2+5, 14+7), images[2].At(5, 7); got != want {
+		t.Errorf("third image At(5, 7) = %v, want %v", got, want)
+	}
+	if !ContactSheet(nil, 4, 2).Bounds().Empty() {
+		t.Error("empty contact sheet is not empty")
+	}
+}
@@ -0,0 +1,106 @@
+package gallery
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// Perlin is gradient noise, the improved noise of Ken Perlin,
+// summing octaves of finer and fainter noise.
+type Perlin struct {
+	Canvas
+	Scale   float64 // size in pixels of the coarsest features, 64 if zero
+	Octaves int     // 1 if zero
+
+	perm [512]uint8
+}
+
+// NewPerlin returns the Perlin noise of seed on c, with
+// features of a quarter of the canvas and 4 octaves.
+func NewPerlin(c Canvas, seed int64) *Perlin {
+	p := &Perlin{Canvas: c, Scale: float64(max(c.Width, c.Height, 4)) / 4, Octaves: 4}
+	for i, v := range rand.New(rand.NewSource(seed)).Perm(256) {
+		p.perm[i] = uint8(v)
+		p.perm[i+256] = uint8(v)
+	}
+	return p
+}
+
+func (p *Perlin) At(x, y int) color.Color {
+	var sum, amplitude, total float64 = 0, 1, 0
+	freq := 1 / 64.0
+	if p.Scale > 0 {
+		freq = 1 / p.Scale
+	}
+	for o := 0; o < max(1, p.Octaves); o++ {
+		sum += amplitude * p.noise(float64(x)*freq, float64(y)*freq)
+		total += amplitude
+		amplitude /= 2
+		freq *= 2
+	}
+	// The noise is about in [-0.7, 0.7].
+	return p.Color(uint8(255 * math.Max(0, math.Min(1, 0.5+0.7*sum/total))))
+}
+
+// noise returns the noise at (x, y), between -1 and 1.
+func (p *Perlin) noise(x, y float64) float64 {
+	xf, yf := math.Floor(x), math.Floor(y)
+	xi, yi := int(xf)&255, int(yf)&255
+	x, y = x-xf, y-yf
+	u, v := fade(x), fade(y)
+	aa := p.perm[int(p.perm[xi])+yi]
+	ab := p.perm[int(p.perm[xi])+yi+1]
+	ba := p.perm[int(p.perm[xi+1])+yi]
+	bb := p.perm[int(p.perm[xi+1])+yi+1]
+	return lerp(v,
+		lerp(u, grad(aa, x, y), grad(ba, x-1, y)),
+		lerp(u, grad(ab, x, y-1), grad(bb, x-1, y-1)))
+}
+
+func fade(t float64) float64 { return t * t * t * (t*(t*6-15) + 10) }
+
+func lerp(t, a, b float64) float64 { return a + t*(b-a) }
+
+// grad returns the dot product of (x, y) with one of 8
+// gradients, picked by hash.
+func grad(hash uint8, x, y float64) float64 {
+	switch hash & 7 {
+	case 0:
+		return x + y
+	case 1:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x
+	case 5:
+		return -x
+	case 6:
+		return y
+	}
+	return -y
+}
+
+// Plasma sums sine waves, for the plasma effect of the demoscene.
+// Changing Phase makes the plasma flow.
+type Plasma struct {
+	Canvas
+	Phase float64
+}
+
+func (m Plasma) At(x, y int) color.Color {
+	// Scale the waves with the canvas, so that it
+	// looks the same at every size.
+	s := 64 / float64(max(m.Width, m.Height, 1))
+	fx, fy := float64(x)*s, float64(y)*s
+	cx, cy := fx-32+8*math.Sin(m.Phase/3), fy-32+8*math.Cos(m.Phase/2)
+	v := math.Sin(fx/4+m.Phase) +
+		math.Sin((fy/2+m.Phase)/2) +
+		math.Sin((fx+fy+m.Phase)/4) +
+		math.Sin(math.Sqrt(cx*cx+cy*cy)/2+m.Phase)
+	// v is in [-4, 4].
+	return m.Color(uint8(255 * (v + 4) / 8))
+}
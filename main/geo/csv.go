@@ -0,0 +1,98 @@
+package geo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golearning/errs"
+)
+
+// ReadCSV reads places from CSV records of a name, a latitude and a
+// longitude. A first record naming the columns, like name,lat,long or
+// latitude,longitude,city, gives their order; the other columns are
+// ignored. It skips the invalid records, and reports them in an
+// errs.List.
+func ReadCSV(r io.Reader) (map[string]Point, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	name, lat, long := 0, 1, 2
+	places := make(map[string]Point)
+	var list errs.List
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			list = list.Append(errs.Wrap(err, errs.Invalid, "read CSV"))
+			if _, ok := err.(*csv.ParseError); ok {
+				continue
+			}
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		if first {
+			if columns, ok := header(record); ok {
+				name, lat, long = columns[0], columns[1], columns[2]
+				continue
+			}
+		}
+		if len(record) <= max(name, lat, long) {
+			list = list.Append(errs.Errorf(errs.Invalid, "line %d: %d fields, want a name, a latitude and a longitude", line, len(record)))
+			continue
+		}
+		p, err := parseLatLong(record[lat], record[long])
+		if err != nil {
+			list = list.Append(errs.Wrap(err, errs.Invalid, fmt.Sprintf("line %d (%s)", line, record[name])))
+			continue
+		}
+		places[record[name]] = p
+	}
+	return places, list.Err()
+}
+
+// header returns the columns of the name, latitude and longitude of
+// the first record, if it names them.
+func header(record []string) (columns [3]int, ok bool) {
+	columns = [3]int{-1, -1, -1}
+	for i, field := range record {
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "name", "place", "city":
+			columns[0] = i
+		case "lat", "latitude":
+			columns[1] = i
+		case "long", "lon", "lng", "longitude":
+			columns[2] = i
+		}
+	}
+	return columns, columns[0] >= 0 && columns[1] >= 0 && columns[2] >= 0
+}
+
+// ParsePoint parses a point written "lat,long", like 40.68433,-74.39967,
+// and validates it.
+func ParsePoint(s string) (Point, error) {
+	lat, long, ok := strings.Cut(s, ",")
+	if !ok {
+		return Point{}, errs.Errorf(errs.Invalid, "point %q: want lat,long", s)
+	}
+	p, err := parseLatLong(lat, long)
+	if err != nil {
+		return Point{}, errs.Wrap(err, errs.Invalid, fmt.Sprintf("point %q", s))
+	}
+	return p, nil
+}
+
+func parseLatLong(lat, long string) (Point, error) {
+	var p Point
+	var err1, err2 error
+	p.Lat, err1 = strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	p.Long, err2 = strconv.ParseFloat(strings.TrimSpace(long), 64)
+	if err := errors.Join(err1, err2); err != nil {
+		return Point{}, err
+	}
+	return p, p.Validate()
+}
@@ -0,0 +1,161 @@
+// Package geo computes with geographic coordinates, like the Lat and
+// Long of Vertex2 in the maps lesson: distances on the sphere and on
+// the WGS 84 ellipsoid, bearings, destinations, bounding boxes and
+// nearest places, and reads and writes places as GeoJSON.
+//
+// Angles are in degrees, distances in meters.
+package geo
+
+import (
+	"math"
+	"sort"
+
+	"golearning/errs"
+)
+
+// Point is a position on Earth: its latitude, from -90 to 90,
+// positive to the north, and its longitude, from -180 to 180,
+// positive to the east.
+type Point struct {
+	Lat, Long float64
+}
+
+// EarthRadius is the mean radius of the Earth, in meters.
+const EarthRadius = 6371008.8
+
+// Validate returns an error with the code errs.Invalid if p is
+// out of range.
+func (p Point) Validate() error {
+	switch {
+	case !(-90 <= p.Lat && p.Lat <= 90):
+		return errs.Errorf(errs.Invalid, "latitude %v out of [-90, 90]", p.Lat)
+	case !(-180 <= p.Long && p.Long <= 180):
+		return errs.Errorf(errs.Invalid, "longitude %v out of [-180, 180]", p.Long)
+	}
+	return nil
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// wrap returns the longitude long brought back to [-180, 180).
+func wrap(long float64) float64 {
+	return math.Mod(math.Mod(long+180, 360)+360, 360) - 180
+}
+
+// Haversine returns the distance between a and b along the great
+// circle through them, on a sphere of radius EarthRadius. It is
+// accurate to about 0.5%, the Earth being flattened at the poles.
+func Haversine(a, b Point) float64 {
+	φ1, φ2 := radians(a.Lat), radians(b.Lat)
+	Δφ, Δλ := φ2-φ1, radians(b.Long-a.Long)
+	h := math.Sin(Δφ/2)*math.Sin(Δφ/2) + math.Cos(φ1)*math.Cos(φ2)*math.Sin(Δλ/2)*math.Sin(Δλ/2)
+	return 2 * EarthRadius * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// Bearing returns the initial bearing from a to b: the direction to
+// follow from a along the great circle to b, in degrees clockwise
+// from the north, in [0, 360).
+func Bearing(a, b Point) float64 {
+	φ1, φ2 := radians(a.Lat), radians(b.Lat)
+	Δλ := radians(b.Long - a.Long)
+	y := math.Sin(Δλ) * math.Cos(φ2)
+	x := math.Cos(φ1)*math.Sin(φ2) - math.Sin(φ1)*math.Cos(φ2)*math.Cos(Δλ)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// Destination returns the point reached from p by going distance
+// meters along the great circle of initial bearing, in degrees.
+func Destination(p Point, bearing, distance float64) Point {
+	φ1, λ1 := radians(p.Lat), radians(p.Long)
+	θ, δ := radians(bearing), distance/EarthRadius
+	φ2 := math.Asin(math.Sin(φ1)*math.Cos(δ) + math.Cos(φ1)*math.Sin(δ)*math.Cos(θ))
+	λ2 := λ1 + math.Atan2(math.Sin(θ)*math.Sin(δ)*math.Cos(φ1), math.Cos(δ)-math.Sin(φ1)*math.Sin(φ2))
+	return Point{degrees(φ2), wrap(degrees(λ2))}
+}
+
+// Box is a bounding box, from its south-west corner Min to its
+// north-east corner Max. A box crossing the antimeridian, the
+// longitude 180, has Min.Long > Max.Long.
+type Box struct {
+	Min, Max Point
+}
+
+// Contains reports whether p is in b.
+func (b Box) Contains(p Point) bool {
+	if p.Lat < b.Min.Lat || p.Lat > b.Max.Lat {
+		return false
+	}
+	if b.Min.Long <= b.Max.Long {
+		return b.Min.Long <= p.Long && p.Long <= b.Max.Long
+	}
+	return p.Long >= b.Min.Long || p.Long <= b.Max.Long
+}
+
+// Around returns the smallest box holding the points within radius
+// meters of p. Near a pole, the box spans every longitude.
+func Around(p Point, radius float64) Box {
+	δ := degrees(radius / EarthRadius)
+	minLat, maxLat := p.Lat-δ, p.Lat+δ
+	if minLat <= -90 || maxLat >= 90 {
+		return Box{Point{math.Max(minLat, -90), -180}, Point{math.Min(maxLat, 90), 180}}
+	}
+	// The largest difference of longitude is at the latitude where
+	// the great circle is tangent to the meridian.
+	Δλ := degrees(math.Asin(math.Sin(radius/EarthRadius) / math.Cos(radians(p.Lat))))
+	if Δλ >= 180 {
+		return Box{Point{minLat, -180}, Point{maxLat, 180}}
+	}
+	return Box{Point{minLat, wrap(p.Long - Δλ)}, Point{maxLat, wrap(p.Long + Δλ)}}
+}
+
+// Bounds returns the smallest box holding the points, not
+// crossing the antimeridian.
+func Bounds(points ...Point) Box {
+	if len(points) == 0 {
+		return Box{}
+	}
+	b := Box{points[0], points[0]}
+	for _, p := range points[1:] {
+		b.Min.Lat, b.Max.Lat = math.Min(b.Min.Lat, p.Lat), math.Max(b.Max.Lat, p.Lat)
+		b.Min.Long, b.Max.Long = math.Min(b.Min.Long, p.Long), math.Max(b.Max.Long, p.Long)
+	}
+	return b
+}
+
+// Nearest returns the name of the place nearest to p, by Haversine,
+// and its distance. Places at the same distance are ordered by name.
+// ok is false if there is no place.
+func Nearest(places map[string]Point, p Point) (name string, distance float64, ok bool) {
+	for n, q := range places {
+		d := Haversine(p, q)
+		if !ok || d < distance || d == distance && n < name {
+			name, distance, ok = n, d, true
+		}
+	}
+	return name, distance, ok
+}
+
+// A Place is a named point, and its distance to another point.
+type Place struct {
+	Name     string
+	Point    Point
+	Distance float64
+}
+
+// ByDistance returns the places sorted by their distance to p,
+// by Haversine, then by name.
+func ByDistance(places map[string]Point, p Point) []Place {
+	sorted := make([]Place, 0, len(places))
+	for name, q := range places {
+		sorted = append(sorted, Place{name, q, Haversine(p, q)})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Distance != sorted[j].Distance {
+			return sorted[i].Distance < sorted[j].Distance
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
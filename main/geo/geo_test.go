@@ -0,0 +1,296 @@
+package geo
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"golearning/errs"
+)
+
+func dms(d, m, s float64) float64 {
+	if d < 0 {
+		return d - m/60 - s/3600
+	}
+	return d + m/60 + s/3600
+}
+
+func near(a, b, tolerance float64) bool { return math.Abs(a-b) <= tolerance }
+
+func TestValidate(t *testing.T) {
+	for _, test := range []struct {
+		p  Point
+		ok bool
+	}{
+		{Point{0, 0}, true},
+		{Point{90, 180}, true},
+		{Point{-90, -180}, true},
+		{Point{90.1, 0}, false},
+		{Point{0, -180.1}, false},
+		{Point{math.NaN(), 0}, false},
+	} {
+		err := test.p.Validate()
+		if (err == nil) != test.ok {
+			t.Errorf("%v.Validate() = %v", test.p, err)
+		}
+		if err != nil && !errors.Is(err, errs.Invalid) {
+			t.Errorf("%v.Validate() = %v, want code %s", test.p, err, errs.Invalid)
+		}
+	}
+}
+
+func TestHaversine(t *testing.T) {
+	degree := EarthRadius * math.Pi / 180
+	for _, test := range []struct {
+		a, b Point
+		want float64
+	}{
+		{Point{0, 0}, Point{0, 0}, 0},
+		{Point{0, 0}, Point{0, 1}, degree},
+		{Point{0, 179.5}, Point{0, -179.5}, degree},
+		{Point{0, 0}, Point{90, 0}, 90 * degree},
+		{Point{-90, 0}, Point{90, 0}, 180 * degree},
+	} {
+		if got := Haversine(test.a, test.b); !near(got, test.want, 1e-6) {
+			t.Errorf("Haversine(%v, %v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestVincenty(t *testing.T) {
+	// The example of Vincenty's paper, from Flinders Peak to Buninyong.
+	flinders := Point{dms(-37, 57, 3.72030), dms(144, 25, 29.52440)}
+	buninyong := Point{dms(-37, 39, 10.15610), dms(143, 55, 35.38390)}
+	for _, test := range []struct {
+		a, b Point
+		want float64
+	}{
+		{flinders, buninyong, 54972.271},
+		{Point{0, 0}, Point{0, 1}, wgs84A * math.Pi / 180},
+		{Point{0, 0}, Point{90, 0}, 10001965.729}, // a quarter of meridian
+		{flinders, flinders, 0},
+	} {
+		got, err := Vincenty(test.a, test.b)
+		if err != nil || !near(got, test.want, 1e-3) {
+			t.Errorf("Vincenty(%v, %v) = %v, %v, want %v", test.a, test.b, got, err, test.want)
+		}
+	}
+
+	a, b := Point{0, 0}, Point{0.5, 179.7}
+	got, err := Vincenty(a, b)
+	if !errors.Is(err, errs.Unavailable) || got != Haversine(a, b) {
+		t.Errorf("Vincenty(%v, %v) = %v, %v, want Haversine and %s", a, b, got, err, errs.Unavailable)
+	}
+}
+
+func TestBearing(t *testing.T) {
+	for _, test := range []struct {
+		a, b Point
+		want float64
+	}{
+		{Point{0, 0}, Point{1, 0}, 0},
+		{Point{0, 0}, Point{0, 1}, 90},
+		{Point{0, 0}, Point{-1, 0}, 180},
+		{Point{0, 0}, Point{0, -1}, 270},
+		{Point{0, 179}, Point{0, -179}, 90},
+		// Along the great circle, west to east in the north goes up first.
+		{Point{45, -10}, Point{45, 10}, 82.9},
+	} {
+		if got := Bearing(test.a, test.b); !near(got, test.want, 0.05) {
+			t.Errorf("Bearing(%v, %v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestDestination(t *testing.T) {
+	for _, test := range []struct {
+		p                 Point
+		bearing, distance float64
+		want              Point
+	}{
+		{Point{0, 0}, 90, EarthRadius * math.Pi / 2, Point{0, 90}},
+		{Point{0, 0}, 0, EarthRadius * math.Pi / 4, Point{45, 0}},
+		{Point{0, 170}, 90, EarthRadius * math.Pi / 9, Point{0, -170}},
+	} {
+		got := Destination(test.p, test.bearing, test.distance)
+		if !near(got.Lat, test.want.Lat, 1e-9) || !near(got.Long, test.want.Long, 1e-9) {
+			t.Errorf("Destination(%v, %v, %v) = %v, want %v", test.p, test.bearing, test.distance, got, test.want)
+		}
+	}
+
+	// Going from a to b along the bearing and distance from a to b.
+	a, b := Point{40.68433, -74.39967}, Point{37.42202, -122.08408}
+	got := Destination(a, Bearing(a, b), Haversine(a, b))
+	if Haversine(got, b) > 1e-3 {
+		t.Errorf("Destination to %v = %v", b, got)
+	}
+}
+
+func TestAround(t *testing.T) {
+	p := Point{37.42202, -122.08408}
+	box := Around(p, 10e3)
+	for bearing := 0.0; bearing < 360; bearing += 5 {
+		// Just inside, the points due north and south being on the box.
+		if q := Destination(p, bearing, 10e3-1e-6); !box.Contains(q) {
+			t.Errorf("Around(%v, 10 km) = %v does not contain %v, at %v°", p, box, q, bearing)
+		}
+	}
+	if q := Destination(p, 45, 15e3); box.Contains(q) {
+		t.Errorf("Around(%v, 10 km) = %v contains %v, 15 km away", p, box, q)
+	}
+
+	// Across the antimeridian, and at a pole.
+	box = Around(Point{0, 179.95}, 20e3)
+	if box.Min.Long < box.Max.Long || !box.Contains(Point{0, -179.95}) || box.Contains(Point{0, 0}) {
+		t.Errorf("Around(0 179.95, 20 km) = %v", box)
+	}
+	box = Around(Point{89.95, 0}, 20e3)
+	if box.Max.Lat != 90 || box.Min.Long != -180 || box.Max.Long != 180 {
+		t.Errorf("Around(89.95 0, 20 km) = %v", box)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	got := Bounds(Point{1, 5}, Point{-2, 3}, Point{0, 7})
+	want := Box{Point{-2, 3}, Point{1, 7}}
+	if got != want {
+		t.Errorf("Bounds = %v, want %v", got, want)
+	}
+	if (Bounds() != Box{}) {
+		t.Errorf("Bounds() = %v, want zero", Bounds())
+	}
+}
+
+var offices = map[string]Point{
+	"Bell Labs": {40.68433, -74.39967},
+	"Google":    {37.42202, -122.08408},
+	"Paris":     {48.8566, 2.3522},
+	"Sydney":    {-33.8688, 151.2093},
+}
+
+func TestNearest(t *testing.T) {
+	for _, test := range []struct {
+		p    Point
+		want string
+	}{
+		{Point{51.5, -0.12}, "Paris"},       // London
+		{Point{40.71, -74.01}, "Bell Labs"}, // New York
+		{Point{21.3, -157.85}, "Google"},    // Honolulu
+		{Point{-36.85, 174.76}, "Sydney"},   // Auckland
+		{Point{37.42202, -122.08408}, "Google"},
+	} {
+		name, d, ok := Nearest(offices, test.p)
+		if !ok || name != test.want || d != Haversine(test.p, offices[name]) {
+			t.Errorf("Nearest(%v) = %q, %v, %v, want %q", test.p, name, d, ok, test.want)
+		}
+	}
+	if _, _, ok := Nearest(nil, Point{}); ok {
+		t.Errorf("Nearest(nil) ok")
+	}
+	// Ties go to the first name.
+	tie := map[string]Point{"b": {0, 1}, "a": {0, -1}, "c": {1, 0}}
+	if name, _, _ := Nearest(tie, Point{}); name != "a" {
+		t.Errorf("Nearest(tie) = %q, want a", name)
+	}
+}
+
+func TestByDistance(t *testing.T) {
+	var names []string
+	for _, p := range ByDistance(offices, Point{51.5, -0.12}) {
+		names = append(names, p.Name)
+	}
+	if got, want := strings.Join(names, ", "), "Paris, Bell Labs, Google, Sydney"; got != want {
+		t.Errorf("ByDistance(London) = %s, want %s", got, want)
+	}
+}
+
+func TestGeoJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteGeoJSON(&b, offices); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `"coordinates": [
+          -74.39967,
+          40.68433
+        ]`) {
+		t.Errorf("WriteGeoJSON: no longitude, latitude of Bell Labs in\n%s", &b)
+	}
+	places, err := ReadGeoJSON(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(places) != len(offices) {
+		t.Errorf("ReadGeoJSON = %v, want %v", places, offices)
+	}
+	for name, p := range offices {
+		if places[name] != p {
+			t.Errorf("ReadGeoJSON: %s = %v, want %v", name, places[name], p)
+		}
+	}
+}
+
+func TestReadGeoJSONErrors(t *testing.T) {
+	const input = `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [2.3522, 48.8566]}, "properties": {"name": "Paris"}},
+		{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}, "properties": {"name": "line"}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0]}, "properties": {"name": "short"}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 100]}, "properties": {"name": "north"}}
+	]}`
+	places, err := ReadGeoJSON(strings.NewReader(input))
+	if len(places) != 1 || places["Paris"] != (Point{48.8566, 2.3522}) {
+		t.Errorf("ReadGeoJSON = %v, want Paris only", places)
+	}
+	var list errs.List
+	if !errors.As(err, &list) || len(list) != 3 {
+		t.Fatalf("ReadGeoJSON error = %v, want 3 errors", err)
+	}
+	if msg := list[2].Error(); !strings.Contains(msg, "feature 4 (north)") || !strings.Contains(msg, "latitude 100") {
+		t.Errorf("error = %q", msg)
+	}
+
+	for _, input := range []string{`{`, `{"type": "Feature"}`} {
+		if _, err := ReadGeoJSON(strings.NewReader(input)); !errors.Is(err, errs.Invalid) {
+			t.Errorf("ReadGeoJSON(%s) = %v, want %s", input, err, errs.Invalid)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	for _, input := range []string{
+		"Bell Labs,40.68433,-74.39967\nGoogle, 37.42202 , -122.08408\n",
+		"name,lat,long\nBell Labs,40.68433,-74.39967\nGoogle,37.42202,-122.08408\n",
+		"Longitude;Latitude;City\n-74.39967;40.68433;Bell Labs\n-122.08408;37.42202;Google\n",
+	} {
+		places, err := ReadCSV(strings.NewReader(strings.ReplaceAll(input, ";", ",")))
+		if err != nil || len(places) != 2 || places["Bell Labs"] != offices["Bell Labs"] || places["Google"] != offices["Google"] {
+			t.Errorf("ReadCSV(%q) = %v, %v", input, places, err)
+		}
+	}
+
+	const input = "name,lat,long\nParis,48.8566,2.3522\nshort,1\nnorth,100,0\nx,y,z\n\"bad,quote\n"
+	places, err := ReadCSV(strings.NewReader(input))
+	if len(places) != 1 || places["Paris"] != offices["Paris"] {
+		t.Errorf("ReadCSV = %v, want Paris only", places)
+	}
+	var list errs.List
+	if !errors.As(err, &list) || len(list) != 4 {
+		t.Fatalf("ReadCSV error = %v, want 4 errors", err)
+	}
+	if msg := list[1].Error(); !strings.Contains(msg, "line 4 (north)") || !strings.Contains(msg, "latitude 100") {
+		t.Errorf("error = %q", msg)
+	}
+}
+
+func TestParsePoint(t *testing.T) {
+	if p, err := ParsePoint(" 40.68433, -74.39967"); err != nil || p != offices["Bell Labs"] {
+		t.Errorf("ParsePoint = %v, %v", p, err)
+	}
+	for _, s := range []string{"", "40.68433", "x,0", "91,0"} {
+		if _, err := ParsePoint(s); !errors.Is(err, errs.Invalid) {
+			t.Errorf("ParsePoint(%q) = %v, want %s", s, err, errs.Invalid)
+		}
+	}
+}
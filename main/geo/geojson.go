@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"golearning/errs"
+)
+
+// The GeoJSON objects of RFC 7946 that ReadGeoJSON and WriteGeoJSON use.
+type (
+	featureCollection struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}
+	feature struct {
+		Type       string         `json:"type"`
+		Geometry   geometry       `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	}
+	// geometry decodes its coordinates only for a Point, the others
+	// having arrays of positions.
+	geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+)
+
+// WriteGeoJSON writes the places to w as a GeoJSON FeatureCollection
+// of Point features, sorted by name, each with its name in the "name"
+// property. GeoJSON puts the longitude before the latitude.
+func WriteGeoJSON(w io.Writer, places map[string]Point) error {
+	names := make([]string, 0, len(places))
+	for name := range places {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fc := featureCollection{Type: "FeatureCollection", Features: []feature{}}
+	for _, name := range names {
+		p := places[name]
+		coordinates, err := json.Marshal([]float64{p.Long, p.Lat})
+		if err != nil {
+			return errs.Wrap(err, errs.Invalid, "write GeoJSON "+name)
+		}
+		fc.Features = append(fc.Features, feature{
+			Type:       "Feature",
+			Geometry:   geometry{"Point", coordinates},
+			Properties: map[string]any{"name": name},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(fc)
+}
+
+// ReadGeoJSON reads places from a GeoJSON FeatureCollection of Point
+// features, named by their "name" property. It skips the features of
+// other geometries, and reports every invalid feature in an errs.List.
+func ReadGeoJSON(r io.Reader) (map[string]Point, error) {
+	var fc featureCollection
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, errs.Wrap(err, errs.Invalid, "read GeoJSON")
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, errs.Errorf(errs.Invalid, "GeoJSON of type %q, want a FeatureCollection", fc.Type)
+	}
+	places := make(map[string]Point)
+	var list errs.List
+	for i, f := range fc.Features {
+		if f.Geometry.Type != "Point" {
+			continue
+		}
+		name, _ := f.Properties["name"].(string)
+		if name == "" {
+			list = list.Append(errs.Errorf(errs.Invalid, "feature %d: no name", i))
+			continue
+		}
+		var coordinates []float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &coordinates); err != nil || len(coordinates) < 2 {
+			list = list.Append(errs.Errorf(errs.Invalid, "feature %d (%s): coordinates %s, want [longitude, latitude]", i, name, f.Geometry.Coordinates))
+			continue
+		}
+		p := Point{Lat: coordinates[1], Long: coordinates[0]}
+		if err := p.Validate(); err != nil {
+			list = list.Append(errs.Wrap(err, errs.Invalid, fmt.Sprintf("feature %d (%s)", i, name)))
+			continue
+		}
+		places[name] = p
+	}
+	return places, list.Err()
+}
@@ -0,0 +1,59 @@
+package geo
+
+import (
+	"math"
+
+	"golearning/errs"
+)
+
+// The WGS 84 ellipsoid, the one of GPS.
+const (
+	wgs84A = 6378137.0         // semi-major axis, in meters
+	wgs84F = 1 / 298.257223563 // flattening
+	wgs84B = wgs84A * (1 - wgs84F)
+)
+
+// Vincenty returns the distance between a and b on the WGS 84
+// ellipsoid, by the inverse formula of Vincenty, accurate to less
+// than a millimeter. The iteration of the formula may not converge
+// for nearly antipodal points: Vincenty then returns an error with
+// the code errs.Unavailable, and the distance by Haversine.
+func Vincenty(a, b Point) (float64, error) {
+	if a == b {
+		return 0, nil
+	}
+	L := radians(b.Long - a.Long)
+	U1 := math.Atan((1 - wgs84F) * math.Tan(radians(a.Lat)))
+	U2 := math.Atan((1 - wgs84F) * math.Tan(radians(b.Lat)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	λ := L
+	for i := 0; i < 200; i++ {
+		sinλ, cosλ := math.Sincos(λ)
+		sinσ := math.Hypot(cosU2*sinλ, cosU1*sinU2-sinU1*cosU2*cosλ)
+		if sinσ == 0 {
+			return 0, nil // coincident points
+		}
+		cosσ := sinU1*sinU2 + cosU1*cosU2*cosλ
+		σ := math.Atan2(sinσ, cosσ)
+		sinα := cosU1 * cosU2 * sinλ / sinσ
+		cos2α := 1 - sinα*sinα
+		cos2σm := 0.0 // on the equator
+		if cos2α != 0 {
+			cos2σm = cosσ - 2*sinU1*sinU2/cos2α
+		}
+		C := wgs84F / 16 * cos2α * (4 + wgs84F*(4-3*cos2α))
+		prev := λ
+		λ = L + (1-C)*wgs84F*sinα*(σ+C*sinσ*(cos2σm+C*cosσ*(-1+2*cos2σm*cos2σm)))
+		if math.Abs(λ-prev) < 1e-12 {
+			u2 := cos2α * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+			A := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+			B := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+			Δσ := B * sinσ * (cos2σm + B/4*(cosσ*(-1+2*cos2σm*cos2σm)-
+				B/6*cos2σm*(-3+4*sinσ*sinσ)*(-3+4*cos2σm*cos2σm)))
+			return wgs84B * A * (σ - Δσ), nil
+		}
+	}
+	return Haversine(a, b), errs.New(errs.Unavailable, "Vincenty's formula does not converge for nearly antipodal points")
+}
@@ -0,0 +1,158 @@
+package geometry
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+)
+
+const eps = 1e-12
+
+func near(a, b float64) bool { return math.Abs(a-b) < eps }
+
+func near2(a, b Vec2[float64]) bool { return near(a.X, b.X) && near(a.Y, b.Y) }
+
+func near3(a, b Vec3[float64]) bool { return near(a.X, b.X) && near(a.Y, b.Y) && near(a.Z, b.Z) }
+
+type abser interface{ Abs() float64 }
+
+func TestVec2(t *testing.T) {
+	v, w := V2(3, 4), V2(1, -2)
+	if got := v.Add(w); got != V2(4, 2) {
+		t.Errorf("Add = %v", got)
+	}
+	if got := v.Sub(w); got != V2(2, 6) {
+		t.Errorf("Sub = %v", got)
+	}
+	if got := v.Mul(2); got != V2(6, 8) {
+		t.Errorf("Mul = %v", got)
+	}
+	if v.Dot(w) != -5 || v.Cross(w) != -10 {
+		t.Errorf("Dot, Cross = %v, %v", v.Dot(w), v.Cross(w))
+	}
+	if v.Len() != 5 || v.Dist(w) != math.Sqrt(40) {
+		t.Errorf("Len, Dist = %v, %v", v.Len(), v.Dist(w))
+	}
+	if got := v.Normalize(); !near2(got, V2(0.6, 0.8)) {
+		t.Errorf("Normalize = %v", got)
+	}
+	if got := V2(0, 0).Normalize(); got != (Vec2[float64]{}) {
+		t.Errorf("Normalize(0) = %v", got)
+	}
+	if got := v.Lerp(w, 0.5); got != V2(2, 1.0) {
+		t.Errorf("Lerp = %v", got)
+	}
+	if got := V2(1, 0).Rotate(math.Pi / 2); !near2(got, V2(0.0, 1)) {
+		t.Errorf("Rotate = %v", got)
+	}
+	if a := V2(1, 0).AngleTo(V2(0, 1)); !near(a, math.Pi/2) {
+		t.Errorf("AngleTo = %v", a)
+	}
+	if a := V2(0, 1).AngleTo(V2(1, 0)); !near(a, -math.Pi/2) {
+		t.Errorf("AngleTo = %v", a)
+	}
+	if a := V2(-1, 0).Angle(); !near(a, math.Pi) {
+		t.Errorf("Angle = %v", a)
+	}
+}
+
+func TestPointerReceivers(t *testing.T) {
+	v := V2(3.0, 4)
+	var a abser = &v // a *Vec2 is an abser, a Vec2 is not
+	v.Scale(10)
+	if a.Abs() != 50 || v != V2(30.0, 40) {
+		t.Errorf("after Scale(10): %v, Abs() = %v", v, a.Abs())
+	}
+	if _, ok := any(v).(abser); ok {
+		t.Error("a Vec2 value is an abser")
+	}
+	u := V3(1, 2, 2)
+	u.Scale(2)
+	if u != V3(2, 4, 4) || u.Abs() != 6 {
+		t.Errorf("Vec3 after Scale(2): %v, Abs() = %v", u, u.Abs())
+	}
+}
+
+func TestVec3(t *testing.T) {
+	x, y, z := V3(1.0, 0, 0), V3(0.0, 1, 0), V3(0.0, 0, 1)
+	if got := x.Cross(y); got != z {
+		t.Errorf("x × y = %v, want z", got)
+	}
+	v, w := V3(1, 2, 3), V3(4, 5, 6)
+	if v.Dot(w) != 32 || v.Add(w) != V3(5, 7, 9) || w.Sub(v) != V3(3, 3, 3) || v.Mul(2) != V3(2, 4, 6) {
+		t.Error("Dot, Add, Sub or Mul is wrong")
+	}
+	if got := v.Dist(w); !near(got, math.Sqrt(27)) {
+		t.Errorf("Dist = %v", got)
+	}
+	if got := x.AngleTo(V3(1.0, 1, 0)); !near(got, math.Pi/4) {
+		t.Errorf("AngleTo = %v", got)
+	}
+	if got := x.Rotate(z, math.Pi/2); !near3(got, y) {
+		t.Errorf("x rotated around z = %v, want y", got)
+	}
+	if got := V3(0, 3, 4).Normalize(); !near3(got, V3(0, 0.6, 0.8)) {
+		t.Errorf("Normalize = %v", got)
+	}
+	if got := x.Lerp(y, 0.25); !near3(got, V3(0.75, 0.25, 0)) {
+		t.Errorf("Lerp = %v", got)
+	}
+}
+
+func TestMatrices(t *testing.T) {
+	r := Rotation2(math.Pi / 2)
+	if got := r.Apply(V2(1.0, 0)); !near2(got, V2(0.0, 1)) {
+		t.Errorf("Rotation2 = %v", got)
+	}
+	m := Scaling2(2, 3).Mul(r)
+	if got := m.Apply(V2(1.0, 0)); !near2(got, V2(0.0, 3)) {
+		t.Errorf("Scaling2·Rotation2 = %v", got)
+	}
+	inv, ok := m.Inverse()
+	if !ok || !near2(inv.Apply(m.Apply(V2(5.0, 7))), V2(5.0, 7)) {
+		t.Errorf("Inverse = %v, %v", inv, ok)
+	}
+	if _, ok := Scaling2(0, 1).Inverse(); ok {
+		t.Error("Scaling2(0, 1) has an inverse")
+	}
+
+	// Translate after rotating.
+	a := Translation2(10, 0).Mul(Affine2(r))
+	if got := a.Transform(V2(1.0, 0)); !near2(got, V2(10.0, 1)) {
+		t.Errorf("Transform = %v", got)
+	}
+	rot := Rotation(V3(1.0, 1, 1), 2*math.Pi/3)
+	if got := rot.Apply(V3(1.0, 0, 0)); !near3(got, V3(0.0, 1, 0)) {
+		t.Errorf("Rotation around (1, 1, 1) = %v", got)
+	}
+	if !near(rot.Det(), 1) || !near3(rot.Mul(rot.Transpose()).Apply(V3(1.0, 2, 3)), V3(1.0, 2, 3)) {
+		t.Error("Rotation is not orthogonal")
+	}
+	if Identity3.Mul(rot) != rot || Identity2.Mul(r) != r {
+		t.Error("the identity changes the matrix")
+	}
+}
+
+func TestStringJSON(t *testing.T) {
+	if s := fmt.Sprint(V2(1, 2), V3(1.5, 0, -2)); s != "(1, 2) (1.5, 0, -2)" {
+		t.Errorf("Sprint = %q", s)
+	}
+	if s := fmt.Sprint(&Vec2[int]{1, 2}); s != "(1, 2)" {
+		t.Errorf("Sprint(pointer) = %q", s)
+	}
+	if s := fmt.Sprintf("%.2f|%5d|%s", V2(1.0, 2.0/3), V3(1, 2, 3), V2(1, 2)); s != "(1.00, 0.67)|(    1,     2,     3)|(1, 2)" {
+		t.Errorf("Sprintf = %q", s)
+	}
+	data, err := json.Marshal(V3(1, 2, 3))
+	if err != nil || string(data) != `{"x":1,"y":2,"z":3}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+	var v Vec2[float64]
+	if err := json.Unmarshal([]byte(`{"x":1.5,"y":-2}`), &v); err != nil || v != V2(1.5, -2) {
+		t.Errorf("Unmarshal = %v, %v", v, err)
+	}
+	if s := Rotation2(0).String(); s != "[1 -0; 0 1]" {
+		t.Errorf("Mat2 String = %q", s)
+	}
+}
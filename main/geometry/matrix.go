@@ -0,0 +1,139 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Mat2 is a 2×2 matrix, row by row, transforming 2D vectors.
+type Mat2 [2][2]float64
+
+// Identity2 is the 2×2 identity matrix.
+var Identity2 = Mat2{{1, 0}, {0, 1}}
+
+// Rotation2 returns the matrix rotating by theta radians, counterclockwise.
+func Rotation2(theta float64) Mat2 {
+	sin, cos := math.Sincos(theta)
+	return Mat2{{cos, -sin}, {sin, cos}}
+}
+
+// Scaling2 returns the matrix scaling by sx along x and sy along y.
+func Scaling2(sx, sy float64) Mat2 {
+	return Mat2{{sx, 0}, {0, sy}}
+}
+
+// Apply returns the product of m and v.
+func (m Mat2) Apply(v Vec2[float64]) Vec2[float64] {
+	return Vec2[float64]{m[0][0]*v.X + m[0][1]*v.Y, m[1][0]*v.X + m[1][1]*v.Y}
+}
+
+// Mul returns the product of m and n: applying it applies n, then m.
+func (m Mat2) Mul(n Mat2) Mat2 {
+	var p Mat2
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			p[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j]
+		}
+	}
+	return p
+}
+
+func (m Mat2) Det() float64 {
+	return m[0][0]*m[1][1] - m[0][1]*m[1][0]
+}
+
+// Inverse returns the inverse of m, and false if m has none.
+func (m Mat2) Inverse() (Mat2, bool) {
+	d := m.Det()
+	if d == 0 {
+		return Mat2{}, false
+	}
+	return Mat2{{m[1][1] / d, -m[0][1] / d}, {-m[1][0] / d, m[0][0] / d}}, true
+}
+
+func (m Mat2) String() string {
+	return fmt.Sprintf("[%v %v; %v %v]", m[0][0], m[0][1], m[1][0], m[1][1])
+}
+
+// Mat3 is a 3×3 matrix, row by row. It transforms 3D vectors, or
+// 2D points in homogeneous coordinates, for the transforms of the
+// plane which are not linear, like translations.
+type Mat3 [3][3]float64
+
+// Identity3 is the 3×3 identity matrix.
+var Identity3 = Mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+// Rotation returns the matrix rotating by theta radians around
+// axis, counterclockwise when axis points towards the viewer,
+// by the formula of Rodrigues. axis must not be zero.
+func Rotation(axis Vec3[float64], theta float64) Mat3 {
+	u := axis.Normalize()
+	sin, cos := math.Sincos(theta)
+	t := 1 - cos
+	return Mat3{
+		{cos + u.X*u.X*t, u.X*u.Y*t - u.Z*sin, u.X*u.Z*t + u.Y*sin},
+		{u.Y*u.X*t + u.Z*sin, cos + u.Y*u.Y*t, u.Y*u.Z*t - u.X*sin},
+		{u.Z*u.X*t - u.Y*sin, u.Z*u.Y*t + u.X*sin, cos + u.Z*u.Z*t},
+	}
+}
+
+// Translation2 returns the matrix translating 2D points by (dx, dy),
+// for Transform.
+func Translation2(dx, dy float64) Mat3 {
+	return Mat3{{1, 0, dx}, {0, 1, dy}, {0, 0, 1}}
+}
+
+// Affine2 returns the matrix applying m to 2D points, for Transform.
+func Affine2(m Mat2) Mat3 {
+	return Mat3{{m[0][0], m[0][1], 0}, {m[1][0], m[1][1], 0}, {0, 0, 1}}
+}
+
+// Apply returns the product of m and v.
+func (m Mat3) Apply(v Vec3[float64]) Vec3[float64] {
+	return Vec3[float64]{
+		m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// Transform returns the 2D point p transformed by m,
+// in homogeneous coordinates.
+func (m Mat3) Transform(p Vec2[float64]) Vec2[float64] {
+	h := m.Apply(Vec3[float64]{p.X, p.Y, 1})
+	return Vec2[float64]{h.X / h.Z, h.Y / h.Z}
+}
+
+// Mul returns the product of m and n: applying it applies n, then m.
+func (m Mat3) Mul(n Mat3) Mat3 {
+	var p Mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				p[i][j] += m[i][k] * n[k][j]
+			}
+		}
+	}
+	return p
+}
+
+func (m Mat3) Transpose() Mat3 {
+	var t Mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t[i][j] = m[j][i]
+		}
+	}
+	return t
+}
+
+func (m Mat3) Det() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+func (m Mat3) String() string {
+	return fmt.Sprintf("[%v %v %v; %v %v %v; %v %v %v]",
+		m[0][0], m[0][1], m[0][2], m[1][0], m[1][1], m[1][2], m[2][0], m[2][1], m[2][2])
+}
@@ -0,0 +1,209 @@
+// Package geometry provides 2D and 3D vectors over any number type,
+// the Vertex types of the tour grown into useful ones, and the
+// matrices transforming them.
+//
+// Abs and Scale have pointer receivers, as the methods of Vertex3
+// in the methods lesson: a *Vec2 is an Abser, a Vec2 is not, and
+// Scale changes the vector it is called on. The other methods have
+// value receivers and return new vectors.
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Number is the constraint of the coordinates of vectors.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Vec2 is a 2D vector.
+type Vec2[T Number] struct {
+	X T `json:"x"`
+	Y T `json:"y"`
+}
+
+// V2 returns the vector (x, y).
+func V2[T Number](x, y T) Vec2[T] {
+	return Vec2[T]{x, y}
+}
+
+func (v Vec2[T]) String() string {
+	return fmt.Sprintf("(%v, %v)", v.X, v.Y)
+}
+
+// Format formats the coordinates of v with the verb, flags and
+// precision, like %.3g, and %s like %v.
+func (v Vec2[T]) Format(f fmt.State, verb rune) {
+	format := coordinateFormat(f, verb)
+	fmt.Fprintf(f, "("+format+", "+format+")", v.X, v.Y)
+}
+
+func (v Vec2[T]) Add(w Vec2[T]) Vec2[T] { return Vec2[T]{v.X + w.X, v.Y + w.Y} }
+func (v Vec2[T]) Sub(w Vec2[T]) Vec2[T] { return Vec2[T]{v.X - w.X, v.Y - w.Y} }
+func (v Vec2[T]) Mul(k T) Vec2[T]       { return Vec2[T]{v.X * k, v.Y * k} }
+func (v Vec2[T]) Dot(w Vec2[T]) T       { return v.X*w.X + v.Y*w.Y }
+
+// Cross returns the z coordinate of the cross product of v and w
+// in 3D: positive when w is counterclockwise from v.
+func (v Vec2[T]) Cross(w Vec2[T]) T { return v.X*w.Y - v.Y*w.X }
+
+// Abs returns the length of v.
+func (v *Vec2[T]) Abs() float64 {
+	return math.Hypot(float64(v.X), float64(v.Y))
+}
+
+// Scale multiplies v by f, in place.
+func (v *Vec2[T]) Scale(f T) {
+	v.X = v.X * f
+	v.Y = v.Y * f
+}
+
+// Len returns the length of v, like Abs, for a vector that
+// is not addressable.
+func (v Vec2[T]) Len() float64 { return v.Abs() }
+
+// Float returns v with float64 coordinates.
+func (v Vec2[T]) Float() Vec2[float64] {
+	return Vec2[float64]{float64(v.X), float64(v.Y)}
+}
+
+// Normalize returns the vector of length 1 in the direction of v,
+// or the zero vector if v is zero.
+func (v Vec2[T]) Normalize() Vec2[float64] {
+	l := v.Len()
+	if l == 0 {
+		return Vec2[float64]{}
+	}
+	return Vec2[float64]{float64(v.X) / l, float64(v.Y) / l}
+}
+
+// Lerp returns the linear interpolation from v, at t = 0, to w, at t = 1.
+func (v Vec2[T]) Lerp(w Vec2[T], t float64) Vec2[float64] {
+	a, b := v.Float(), w.Float()
+	return Vec2[float64]{a.X + (b.X-a.X)*t, a.Y + (b.Y-a.Y)*t}
+}
+
+// Rotate returns v rotated by theta radians, counterclockwise.
+func (v Vec2[T]) Rotate(theta float64) Vec2[float64] {
+	sin, cos := math.Sincos(theta)
+	x, y := float64(v.X), float64(v.Y)
+	return Vec2[float64]{x*cos - y*sin, x*sin + y*cos}
+}
+
+// Angle returns the angle of v with the x axis, in radians in [-π, π].
+func (v Vec2[T]) Angle() float64 {
+	return math.Atan2(float64(v.Y), float64(v.X))
+}
+
+// Dist returns the distance between the points v and w.
+func (v Vec2[T]) Dist(w Vec2[T]) float64 {
+	return math.Hypot(float64(v.X)-float64(w.X), float64(v.Y)-float64(w.Y))
+}
+
+// AngleTo returns the angle from v to w, in radians in [-π, π],
+// positive when counterclockwise.
+func (v Vec2[T]) AngleTo(w Vec2[T]) float64 {
+	a, b := v.Float(), w.Float()
+	return math.Atan2(a.Cross(b), a.Dot(b))
+}
+
+// Vec3 is a 3D vector.
+type Vec3[T Number] struct {
+	X T `json:"x"`
+	Y T `json:"y"`
+	Z T `json:"z"`
+}
+
+// V3 returns the vector (x, y, z).
+func V3[T Number](x, y, z T) Vec3[T] {
+	return Vec3[T]{x, y, z}
+}
+
+func (v Vec3[T]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", v.X, v.Y, v.Z)
+}
+
+// Format formats the coordinates of v with the verb, flags and
+// precision, like %.3g, and %s like %v.
+func (v Vec3[T]) Format(f fmt.State, verb rune) {
+	format := coordinateFormat(f, verb)
+	fmt.Fprintf(f, "("+format+", "+format+", "+format+")", v.X, v.Y, v.Z)
+}
+
+// coordinateFormat returns the format of the coordinates of a
+// vector formatted with the state f and the verb.
+func coordinateFormat(f fmt.State, verb rune) string {
+	if verb == 's' {
+		verb = 'v'
+	}
+	return fmt.FormatString(f, verb)
+}
+
+func (v Vec3[T]) Add(w Vec3[T]) Vec3[T] { return Vec3[T]{v.X + w.X, v.Y + w.Y, v.Z + w.Z} }
+func (v Vec3[T]) Sub(w Vec3[T]) Vec3[T] { return Vec3[T]{v.X - w.X, v.Y - w.Y, v.Z - w.Z} }
+func (v Vec3[T]) Mul(k T) Vec3[T]       { return Vec3[T]{v.X * k, v.Y * k, v.Z * k} }
+func (v Vec3[T]) Dot(w Vec3[T]) T       { return v.X*w.X + v.Y*w.Y + v.Z*w.Z }
+
+// Cross returns the cross product of v and w, orthogonal to both.
+func (v Vec3[T]) Cross(w Vec3[T]) Vec3[T] {
+	return Vec3[T]{v.Y*w.Z - v.Z*w.Y, v.Z*w.X - v.X*w.Z, v.X*w.Y - v.Y*w.X}
+}
+
+// Abs returns the length of v.
+func (v *Vec3[T]) Abs() float64 {
+	x, y, z := float64(v.X), float64(v.Y), float64(v.Z)
+	return math.Sqrt(x*x + y*y + z*z)
+}
+
+// Scale multiplies v by f, in place.
+func (v *Vec3[T]) Scale(f T) {
+	v.X = v.X * f
+	v.Y = v.Y * f
+	v.Z = v.Z * f
+}
+
+// Len returns the length of v, like Abs, for a vector that
+// is not addressable.
+func (v Vec3[T]) Len() float64 { return v.Abs() }
+
+// Float returns v with float64 coordinates.
+func (v Vec3[T]) Float() Vec3[float64] {
+	return Vec3[float64]{float64(v.X), float64(v.Y), float64(v.Z)}
+}
+
+// Normalize returns the vector of length 1 in the direction of v,
+// or the zero vector if v is zero.
+func (v Vec3[T]) Normalize() Vec3[float64] {
+	l := v.Len()
+	if l == 0 {
+		return Vec3[float64]{}
+	}
+	return Vec3[float64]{float64(v.X) / l, float64(v.Y) / l, float64(v.Z) / l}
+}
+
+// Lerp returns the linear interpolation from v, at t = 0, to w, at t = 1.
+func (v Vec3[T]) Lerp(w Vec3[T], t float64) Vec3[float64] {
+	a, b := v.Float(), w.Float()
+	return a.Add(b.Sub(a).Mul(t))
+}
+
+// Rotate returns v rotated by theta radians around axis,
+// counterclockwise when axis points towards the viewer.
+func (v Vec3[T]) Rotate(axis Vec3[T], theta float64) Vec3[float64] {
+	return Rotation(axis.Float(), theta).Apply(v.Float())
+}
+
+// Dist returns the distance between the points v and w.
+func (v Vec3[T]) Dist(w Vec3[T]) float64 {
+	return v.Float().Sub(w.Float()).Len()
+}
+
+// AngleTo returns the angle between v and w, in radians in [0, π].
+func (v Vec3[T]) AngleTo(w Vec3[T]) float64 {
+	a, b := v.Float(), w.Float()
+	return math.Atan2(a.Cross(b).Len(), a.Dot(b))
+}
@@ -1,4 +1,4 @@
-module main
+module golearning
 
 go 1.21.4
 
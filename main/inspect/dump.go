@@ -0,0 +1,93 @@
+package inspect
+
+import "io"
+
+// bytesPerLine is the number of bytes of a line of dump.
+const bytesPerLine = 16
+
+// line returns the line of dump of data, at most bytesPerLine
+// bytes at offset, like xxd prints it:
+//
+//	00000000: 4865 6c6c 6f2c 2052 6561 6465 7221 0a    Hello, Reader!.
+func line(data []byte, offset int64) []byte {
+	const hex = "0123456789abcdef"
+	b := make([]byte, 0, 68)
+	for shift := 28; shift >= 0; shift -= 4 {
+		b = append(b, hex[offset>>shift&0xf])
+	}
+	b = append(b, ':')
+	for i := 0; i < bytesPerLine; i++ {
+		if i%2 == 0 {
+			b = append(b, ' ')
+		}
+		if i < len(data) {
+			b = append(b, hex[data[i]>>4], hex[data[i]&0xf])
+		} else {
+			b = append(b, ' ', ' ')
+		}
+	}
+	b = append(b, ' ', ' ')
+	for _, c := range data {
+		if c < ' ' || c > '~' {
+			c = '.'
+		}
+		b = append(b, c)
+	}
+	return append(b, '\n')
+}
+
+// Dump writes the dump of data to w, numbering the bytes from offset.
+func Dump(w io.Writer, data []byte, offset int64) error {
+	for len(data) > 0 {
+		n := min(len(data), bytesPerLine)
+		if _, err := w.Write(line(data[:n], offset)); err != nil {
+			return err
+		}
+		data = data[n:]
+		offset += int64(n)
+	}
+	return nil
+}
+
+type dumper struct {
+	w      io.Writer
+	offset int64
+	buf    []byte // bytes of the line being filled
+}
+
+// Dumper returns a writer writing the dump of the bytes written
+// to it to w, as xxd does for a whole file. Close writes the last
+// line, if it is not full; it does not close w.
+func Dumper(w io.Writer) io.WriteCloser {
+	return &dumper{w: w, buf: make([]byte, 0, bytesPerLine)}
+}
+
+func (d *dumper) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), bytesPerLine-len(d.buf))
+		d.buf = append(d.buf, p[:n]...)
+		p = p[n:]
+		if len(d.buf) == bytesPerLine {
+			if err := d.flush(); err != nil {
+				return written, err
+			}
+		}
+		written += n
+	}
+	return written, nil
+}
+
+func (d *dumper) flush() error {
+	_, err := d.w.Write(line(d.buf, d.offset))
+	d.offset += int64(len(d.buf))
+	d.buf = d.buf[:0]
+	return err
+}
+
+func (d *dumper) Close() error {
+	if len(d.buf) == 0 {
+		return nil
+	}
+	return d.flush()
+}
@@ -0,0 +1,51 @@
+// Package inspect shows what happens when reading: Reader logs the
+// Read calls made on a reader, like displayReader of the tour does,
+// and Dump and Dumper print bytes in the hexadecimal format of xxd.
+package inspect
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Reader wraps R, and logs each Read call made on it to Log:
+// the size of the buffer, the number of bytes returned, the
+// error, and how long the call took.
+type Reader struct {
+	R    io.Reader
+	Log  io.Writer
+	Dump bool             // also dump the bytes returned by each call
+	Now  func() time.Time // clock timing the calls, time.Now if nil
+
+	calls  int
+	offset int64
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	now := r.Now
+	if now == nil {
+		now = time.Now
+	}
+	start := now()
+	n, err := r.R.Read(p)
+	elapsed := now().Sub(start)
+
+	r.calls++
+	fmt.Fprintf(r.Log, "Read #%d: len(p) = %d, n = %d, err = %v (%v)\n", r.calls, len(p), n, err, elapsed)
+	if r.Dump && n > 0 {
+		Dump(r.Log, p[:n], r.offset)
+	}
+	r.offset += int64(n)
+	return n, err
+}
+
+// Calls returns the number of Read calls made.
+func (r *Reader) Calls() int {
+	return r.calls
+}
+
+// Offset returns the number of bytes read.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
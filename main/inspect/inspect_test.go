@@ -0,0 +1,108 @@
+package inspect
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+const text = "Hello, Reader!\nsecond line of text here\n"
+
+// xxd is the output of xxd for text.
+const xxd = `00000000: 4865 6c6c 6f2c 2052 6561 6465 7221 0a73  Hello, Reader!.s
+00000010: 6563 6f6e 6420 6c69 6e65 206f 6620 7465  econd line of te
+00000020: 7874 2068 6572 650a                      xt here.
+`
+
+func TestDumper(t *testing.T) {
+	// Whatever the sizes of the writes, the lines are the same.
+	for _, size := range []int{1, 5, 16, 17, len(text)} {
+		var b strings.Builder
+		d := Dumper(&b)
+		for s := text; s != ""; {
+			n := min(size, len(s))
+			if n, err := d.Write([]byte(s[:n])); err != nil || n != min(size, len(s)) {
+				t.Fatalf("Write = %d, %v", n, err)
+			}
+			s = s[n:]
+		}
+		if err := d.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != xxd {
+			t.Errorf("writing by %d bytes, dumped\n%s\nwant\n%s", size, b.String(), xxd)
+		}
+	}
+}
+
+func TestDump(t *testing.T) {
+	var b strings.Builder
+	Dump(&b, []byte("\x00\xffé~"), 0x1234)
+	if want := "00001234: 00ff c3a9 7e                             ....~\n"; b.String() != want {
+		t.Errorf("Dump = %q, want %q", b.String(), want)
+	}
+	b.Reset()
+	if Dump(&b, nil, 0); b.Len() != 0 {
+		t.Errorf("Dump(nil) = %q", b.String())
+	}
+}
+
+// tick returns a clock advancing by d at each call.
+func tick(d time.Duration) func() time.Time {
+	var now time.Time
+	return func() time.Time {
+		now = now.Add(d)
+		return now
+	}
+}
+
+func TestReader(t *testing.T) {
+	var log strings.Builder
+	r := &Reader{R: iotest.HalfReader(strings.NewReader("Hello, Reader!")), Log: &log, Now: tick(time.Millisecond)}
+	var got []byte
+	b := make([]byte, 8)
+	for {
+		n, err := r.Read(b)
+		got = append(got, b[:n]...)
+		if err == io.EOF {
+			break
+		}
+	}
+	if string(got) != "Hello, Reader!" {
+		t.Fatalf("read %q through the inspector", got)
+	}
+	if r.Calls() != 5 || r.Offset() != 14 {
+		t.Errorf("Calls, Offset = %d, %d, want 5, 14", r.Calls(), r.Offset())
+	}
+	// HalfReader returns short reads.
+	want := `Read #1: len(p) = 8, n = 4, err = <nil> (1ms)
+Read #2: len(p) = 8, n = 4, err = <nil> (1ms)
+Read #3: len(p) = 8, n = 4, err = <nil> (1ms)
+Read #4: len(p) = 8, n = 2, err = <nil> (1ms)
+Read #5: len(p) = 8, n = 0, err = EOF (1ms)
+`
+	if log.String() != want {
+		t.Errorf("log:\n%s\nwant:\n%s", log.String(), want)
+	}
+}
+
+func TestReaderDump(t *testing.T) {
+	var log bytes.Buffer
+	r := &Reader{R: strings.NewReader(text), Log: &log, Dump: true, Now: tick(0)}
+	buf := make([]byte, 20)
+	r.Read(buf)
+	r.Read(buf)
+	want := `Read #1: len(p) = 20, n = 20, err = <nil> (0s)
+00000000: 4865 6c6c 6f2c 2052 6561 6465 7221 0a73  Hello, Reader!.s
+00000010: 6563 6f6e                                econ
+Read #2: len(p) = 20, n = 20, err = <nil> (0s)
+00000014: 6420 6c69 6e65 206f 6620 7465 7874 2068  d line of text h
+00000024: 6572 650a                                ere.
+`
+	if log.String() != want {
+		t.Errorf("log:\n%s\nwant:\n%s", log.String(), want)
+	}
+}
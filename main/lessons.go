@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Context is given to every step: the step writes its
+// output to it instead of os.Stdout, so the output can be
+// captured, compared or discarded by whoever runs the step.
+//
+// A Context is safe for use by the goroutines a step starts.
+type Context struct {
+	mu  sync.Mutex
+	out io.Writer
+
+	// clock and rand are the only sources of time and randomness
+	// a step may use, so that its output can be reproduced.
+	clock Clock
+	rand  Random
+}
+
+func newContext(out io.Writer) *Context {
+	return &Context{
+		out:   out,
+		clock: systemClock{},
+		rand:  newRandom(time.Now().UnixNano()),
+	}
+}
+
+func (ctx *Context) Write(p []byte) (int, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.out.Write(p)
+}
+
+func (ctx *Context) Print(a ...any) {
+	fmt.Fprint(ctx, a...)
+}
+
+func (ctx *Context) Println(a ...any) {
+	fmt.Fprintln(ctx, a...)
+}
+
+func (ctx *Context) Printf(format string, a ...any) {
+	fmt.Fprintf(ctx, format, a...)
+}
+
+// A step is one demo of a lesson, usually one of the
+// functions of the tour files.
+type step struct {
+	name string
+	run  func(ctx *Context)
+}
+
+// A lesson groups the steps of one tour file,
+// in the order they are shown.
+type lesson struct {
+	name  string
+	title string
+	steps []step
+}
+
+// lessons is the registry of every lesson, in tour order.
+var lessons = []lesson{
+	basics,
+	collections,
+	methods,
+	concurrency,
+	slicesExercise,
+}
+
+func findLesson(name string) (lesson, bool) {
+	for _, l := range lessons {
+		if l.name == name {
+			return l, true
+		}
+	}
+	return lesson{}, false
+}
+
+func (l lesson) step(name string) (step, bool) {
+	for _, s := range l.steps {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return step{}, false
+}
+
+// resolve finds the steps designated by "<lesson>" or "<lesson>/<step>".
+func resolve(target string) ([]step, error) {
+	lessonName, stepName, hasStep := strings.Cut(target, "/")
+	l, ok := findLesson(lessonName)
+	if !ok {
+		return nil, fmt.Errorf("unknown lesson %q", lessonName)
+	}
+	if !hasStep {
+		return l.steps, nil
+	}
+	s, ok := l.step(stepName)
+	if !ok {
+		return nil, fmt.Errorf("unknown step %q in lesson %q", stepName, l.name)
+	}
+	return []step{s}, nil
+}
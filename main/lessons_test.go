@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the .golden files with the current output")
+
+// racy lists the steps whose output depends on how their
+// goroutines are scheduled, or on the toolchain: they cannot
+// have a golden file.
+var racy = map[string]string{
+	"concurrency/say":        "goroutine scheduling",
+	"concurrency/sum":        "goroutine scheduling",
+	"concurrency/fibonacci5": "goroutine scheduling",
+	// The runtime picks the capacities, differently with -race.
+	"collections/appendGrowth": "the capacities of slices",
+}
+
+// newTestContext returns a Context where it is always
+// Saturday 2024-03-09 10:00 UTC and the random source is seeded.
+func newTestContext(out *bytes.Buffer) *Context {
+	ctx := newContext(out)
+	ctx.clock = fixedClock(time.Date(2024, 3, 9, 10, 0, 0, 0, time.UTC))
+	ctx.rand = newRandom(42)
+	return ctx
+}
+
+func TestGolden(t *testing.T) {
+	for _, l := range lessons {
+		for _, s := range l.steps {
+			name := l.name + "/" + s.name
+			run := s.run
+			t.Run(name, func(t *testing.T) {
+				if reason, ok := racy[name]; ok {
+					t.Skip("output depends on " + reason)
+				}
+				var out bytes.Buffer
+				run(newTestContext(&out))
+
+				golden := filepath.Join("testdata", name+".golden")
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(golden, out.Bytes(), 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("%v (run go test -update to create it)", err)
+				}
+				if !bytes.Equal(out.Bytes(), want) {
+					t.Errorf("output differs from %s\ngot:\n%s\nwant:\n%s", golden, out.Bytes(), want)
+				}
+			})
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	steps, err := resolve("collections")
+	if err != nil || len(steps) != len(collections.steps) {
+		t.Errorf(`resolve("collections") = %d steps, %v; want %d steps`, len(steps), err, len(collections.steps))
+	}
+	steps, err = resolve("collections/slices2")
+	if err != nil || len(steps) != 1 || steps[0].name != "slices2" {
+		t.Errorf(`resolve("collections/slices2") = %v, %v; want the slices2 step`, steps, err)
+	}
+	for _, target := range []string{"nope", "collections/nope"} {
+		if _, err := resolve(target); err == nil {
+			t.Errorf("resolve(%q) succeeded, want an error", target)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+// golearning runs the demos of the tour files:
+//
+//	golearning list
+//	golearning run <lesson>
+//	golearning run <lesson>/<step>
+//	golearning check [<exercise>]
+//	golearning tree [--k n] [--seed n] [--in file] [--format ascii|dot|svg|levels|json] [--o file]
+//	golearning crawl [--depth n] [--fake] [<url>]
+//	golearning cipher [--decode] [--shift n] [--key key] caesar|rot13|rot47|atbash|vigenere
+//	golearning inspect [--buf n] [--dump] [--xxd] <file>|-
+//	golearning render [--size WxH] [--colormap name] [--format png|gif|jpeg] [--o file] <picture>
+//	golearning animate [--size WxH] [--frames n] [--delay n] [--colors n] [--dither] [--colormap name] [--o file] <animation>
+//	golearning places [--in file.geojson|file.csv] [--near lat,long [--k n] [--within km]] [--geojson]
+//
+// Without a command, golearning prints this usage, from the
+// commands below.
+//
+// run accepts --now and --seed to pin the time and the random
+// numbers the steps see, e.g. --now 2024-03-09T10:00 --seed 42.
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"list", "list", list},
+	{"run", "run [--now " + nowLayout + "] [--seed n] <lesson>[/<step>]", runLesson},
+	{"check", "check [<exercise>]", checkExercises},
+	{"tree", strings.TrimPrefix(treeUsage, "golearning "), drawTree},
+	{"crawl", strings.TrimPrefix(crawlUsage, "golearning "), crawl},
+	{"cipher", strings.TrimPrefix(cipherUsage, "golearning "), encipher},
+	{"inspect", strings.TrimPrefix(inspectUsage, "golearning "), inspectFile},
+	{"render", strings.TrimPrefix(renderUsage, "golearning "), renderPicture},
+	{"animate", strings.TrimPrefix(animateUsage, "golearning "), animateGIF},
+	{"places", strings.TrimPrefix(placesUsage, "golearning "), listPlaces},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, "golearning:", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "golearning: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	for _, cmd := range commands {
+		fmt.Fprintln(os.Stderr, "  golearning", cmd.usage)
+	}
+}
+
+func list(args []string) error {
+	for _, l := range lessons {
+		fmt.Printf("%-16s %s\n", l.name, l.title)
+		for _, s := range l.steps {
+			fmt.Printf("    %s/%s\n", l.name, s.name)
+		}
+	}
+	return nil
+}
+
+// nowLayout is the layout of the --now flag of the run command.
+const nowLayout = "2006-01-02T15:04"
+
+func runLesson(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	now := fs.String("now", "", "run as if the local time was `"+nowLayout+"`")
+	seed := fs.Int64("seed", 0, "seed the random source with `n` to get the same numbers on every run")
+	// Flags are accepted both before and after the lesson.
+	err := fs.Parse(args)
+	target := fs.Arg(0)
+	if err == nil {
+		err = fs.Parse(fs.Args()[min(1, fs.NArg()):])
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if target == "" || fs.NArg() != 0 {
+		return errors.New("usage: golearning run [--now " + nowLayout + "] [--seed n] <lesson>[/<step>]")
+	}
+	steps, err := resolve(target)
+	if err != nil {
+		return err
+	}
+
+	ctx := newContext(os.Stdout)
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "now":
+			var t time.Time
+			if t, err = time.ParseInLocation(nowLayout, *now, time.Local); err == nil {
+				ctx.clock = fixedClock(t)
+			}
+		case "seed":
+			ctx.rand = newRandom(*seed)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("invalid --now: %w", err)
+	}
+	for _, s := range steps {
+		if len(steps) > 1 {
+			ctx.Printf("--- %s\n", s.name)
+		}
+		s.run(ctx)
+	}
+	return nil
+}
+
+func checkExercises(args []string) error {
+	selected := exercises
+	switch len(args) {
+	case 0:
+	case 1:
+		e, ok := findExercise(args[0])
+		if !ok {
+			return fmt.Errorf("unknown exercise %q", args[0])
+		}
+		selected = []exercise{e}
+	default:
+		return errors.New("usage: golearning check [<exercise>]")
+	}
+	failed := 0
+	for i, e := range selected {
+		if i > 0 {
+			fmt.Println()
+		}
+		failed += grade(os.Stdout, e)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d checks failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+// Package numeric finds the roots of functions of one variable,
+// generalizing the sqrtFinder exercise of the tour: Newton's
+// method, the bisection, secant and Brent's methods, each
+// returning the trace of its iterations.
+package numeric
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrNoConvergence is returned when a solver reaches the
+	// maximum number of iterations without converging.
+	ErrNoConvergence = errors.New("numeric: no convergence")
+	// ErrNotBracketed is returned by the bracketing methods when
+	// the function has the same sign at both ends of the interval.
+	ErrNotBracketed = errors.New("numeric: root not bracketed")
+	// ErrDomain is returned for an argument the result is not
+	// defined for, like an even root of a negative number.
+	ErrDomain = errors.New("numeric: argument out of domain")
+)
+
+// Default options of the solvers.
+const (
+	DefaultTolerance = 1e-12
+	DefaultMaxIter   = 100
+)
+
+// Options configure a solver. The zero value, or nil, uses
+// the default options.
+type Options struct {
+	// Tolerance is the largest step between two iterates, or the
+	// largest width of the bracket, that stops the solver.
+	// It is relative to the magnitude of the root when above 1.
+	// A negative Tolerance runs the solver until the estimate
+	// stops changing.
+	Tolerance float64
+	// MaxIter is the largest number of iterations.
+	MaxIter int
+}
+
+func (o *Options) tolerance() float64 {
+	switch {
+	case o == nil || o.Tolerance == 0:
+		return DefaultTolerance
+	case o.Tolerance < 0:
+		return 0
+	}
+	return o.Tolerance
+}
+
+func (o *Options) maxIter() int {
+	if o == nil || o.MaxIter <= 0 {
+		return DefaultMaxIter
+	}
+	return o.MaxIter
+}
+
+// A Step is an iteration of a solver: the estimate X of the
+// root and the value of the function there.
+type Step struct {
+	X, FX float64
+}
+
+// A Result is the outcome of a solver. Trace holds the estimate
+// of every iteration, the last one being Root.
+type Result struct {
+	Root  float64
+	Trace []Step
+}
+
+// Iterations returns the number of iterations of the solver.
+func (r Result) Iterations() int {
+	return len(r.Trace)
+}
+
+// converged reports whether a step from x of size dx is below
+// the tolerance.
+func converged(x, dx, tol float64) bool {
+	return math.Abs(dx) <= tol*math.Max(1, math.Abs(x))
+}
+
+// Newton finds a root of f with Newton's method, starting from x0:
+// each iteration follows the tangent of f to zero. The derivative
+// df may be nil, in which case it is approximated numerically.
+// Near the root, where rounding makes the steps too small to change
+// the estimate, or makes it go back and forth between two numbers,
+// Newton stops at the number where f is the smallest.
+func Newton(f, df func(float64) float64, x0 float64, opts *Options) (Result, error) {
+	if df == nil {
+		df = Derivative(f)
+	}
+	tol, maxIter := opts.tolerance(), opts.maxIter()
+	var r Result
+	x, before := x0, math.NaN() // before is the iterate before x
+	for i := 0; i < maxIter; i++ {
+		fx := f(x)
+		if fx == 0 {
+			r.Root = x
+			r.Trace = append(r.Trace, Step{x, fx})
+			return r, nil
+		}
+		d := df(x)
+		if d == 0 || math.IsNaN(d) {
+			break
+		}
+		dx := fx / d
+		next := x - dx
+		switch {
+		case next == before:
+			if math.Abs(f(before)) < math.Abs(fx) {
+				r.Root = before
+				r.Trace = append(r.Trace, Step{before, f(before)})
+			}
+			return r, nil
+		case next == x:
+			// The step is too small to change x, but the number
+			// next to x in its direction may still be closer.
+			n := math.Nextafter(x, math.Copysign(math.Inf(1), -dx))
+			if math.Abs(f(n)) >= math.Abs(fx) {
+				r.Root = x
+				r.Trace = append(r.Trace, Step{x, fx})
+				return r, nil
+			}
+			next = n
+		}
+		before, x = x, next
+		r.Root = x
+		r.Trace = append(r.Trace, Step{x, f(x)})
+		if converged(x, dx, tol) {
+			return r, nil
+		}
+	}
+	return r, ErrNoConvergence
+}
+
+// Secant finds a root of f with the secant method, starting from
+// x0 and x1: it is Newton's method with the derivative replaced
+// by the slope through the last two estimates.
+func Secant(f func(float64) float64, x0, x1 float64, opts *Options) (Result, error) {
+	tol, maxIter := opts.tolerance(), opts.maxIter()
+	var r Result
+	f0, f1 := f(x0), f(x1)
+	for i := 0; i < maxIter; i++ {
+		if f1 == 0 {
+			r.Root = x1
+			r.Trace = append(r.Trace, Step{x1, f1})
+			return r, nil
+		}
+		if f1 == f0 {
+			break
+		}
+		dx := f1 * (x1 - x0) / (f1 - f0)
+		x0, f0 = x1, f1
+		x1 -= dx
+		f1 = f(x1)
+		r.Root = x1
+		r.Trace = append(r.Trace, Step{x1, f1})
+		if converged(x1, dx, tol) {
+			return r, nil
+		}
+	}
+	return r, ErrNoConvergence
+}
+
+// Bisection finds a root of f in [a, b] by halving the interval,
+// keeping the half where f changes sign. f(a) and f(b) must have
+// opposite signs. It always converges, but slowly.
+func Bisection(f func(float64) float64, a, b float64, opts *Options) (Result, error) {
+	tol, maxIter := opts.tolerance(), opts.maxIter()
+	fa, fb := f(a), f(b)
+	switch {
+	case fa == 0:
+		return Result{a, []Step{{a, fa}}}, nil
+	case fb == 0:
+		return Result{b, []Step{{b, fb}}}, nil
+	case math.Signbit(fa) == math.Signbit(fb):
+		return Result{}, ErrNotBracketed
+	}
+	var r Result
+	for i := 0; i < maxIter; i++ {
+		m := a + (b-a)/2
+		fm := f(m)
+		r.Root = m
+		r.Trace = append(r.Trace, Step{m, fm})
+		if fm == 0 || converged(m, (b-a)/2, tol) {
+			return r, nil
+		}
+		if math.Signbit(fm) == math.Signbit(fa) {
+			a, fa = m, fm
+		} else {
+			b = m
+		}
+	}
+	return r, ErrNoConvergence
+}
+
+// Brent finds a root of f in [a, b] with Brent's method, which
+// combines bisection with the secant method and inverse quadratic
+// interpolation: as safe as bisection, but usually much faster.
+// f(a) and f(b) must have opposite signs.
+func Brent(f func(float64) float64, a, b float64, opts *Options) (Result, error) {
+	tol, maxIter := opts.tolerance(), opts.maxIter()
+	fa, fb := f(a), f(b)
+	switch {
+	case fa == 0:
+		return Result{a, []Step{{a, fa}}}, nil
+	case fb == 0:
+		return Result{b, []Step{{b, fb}}}, nil
+	case math.Signbit(fa) == math.Signbit(fb):
+		return Result{}, ErrNotBracketed
+	}
+	// b is the best estimate, a the previous one,
+	// and the root is between b and c.
+	c, fc := b, fb
+	var d, e float64
+	var r Result
+	for i := 0; i < maxIter; i++ {
+		if math.Signbit(fb) == math.Signbit(fc) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol1 := 2*epsilon*math.Abs(b) + tol*math.Max(1, math.Abs(b))/2
+		m := (c - b) / 2
+		if math.Abs(m) <= tol1 || fb == 0 {
+			if n := len(r.Trace); n == 0 || r.Trace[n-1].X != b {
+				r.Trace = append(r.Trace, Step{b, fb})
+			}
+			r.Root = b
+			return r, nil
+		}
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			// Interpolate: secant if only two points are known,
+			// inverse quadratic otherwise.
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				q = fa / fc
+				t := fb / fc
+				p = s * (2*m*q*(q-t) - (b-a)*(t-1))
+				q = (q - 1) * (t - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			if 2*p < math.Min(3*m*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = d
+			}
+		} else {
+			d = m
+			e = d
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += math.Copysign(tol1, m)
+		}
+		fb = f(b)
+		r.Root = b
+		r.Trace = append(r.Trace, Step{b, fb})
+	}
+	return r, ErrNoConvergence
+}
+
+// epsilon is the machine epsilon of float64.
+const epsilon = 0x1p-52
+
+// Derivative returns an approximation of the derivative of f,
+// by central differences.
+func Derivative(f func(float64) float64) func(float64) float64 {
+	return func(x float64) float64 {
+		h := math.Cbrt(epsilon) * math.Max(1, math.Abs(x))
+		return (f(x+h) - f(x-h)) / (2 * h)
+	}
+}
+
+// NthRoot returns the real n-th root of x, computed with
+// Newton's method. Odd roots of negative numbers are negative;
+// even roots of negative numbers return ErrDomain.
+func NthRoot(x float64, n int) (float64, error) {
+	switch {
+	case n < 1 || x < 0 && n%2 == 0 || math.IsNaN(x):
+		return math.NaN(), ErrDomain
+	case n == 1 || x == 0 || math.IsInf(x, 0):
+		return x, nil
+	case x < 0:
+		root, err := NthRoot(-x, n)
+		return -root, err
+	}
+	// Solve for the mantissa only, so that the root is between
+	// 0.5 and 2 whatever the magnitude of x: x = m·2^(kn) with
+	// m in [0.5, 2^(n-1)), and its root is m^(1/n)·2^k.
+	frac, exp := math.Frexp(x)
+	r := exp % n
+	if r < 0 {
+		r += n
+	}
+	m, k := math.Ldexp(frac, r), (exp-r)/n
+	f := func(z float64) float64 { return math.Pow(z, float64(n)) - m }
+	df := func(z float64) float64 { return float64(n) * math.Pow(z, float64(n-1)) }
+	// Starting above the root, Newton's method
+	// decreases monotonically towards it.
+	root, err := Newton(f, df, 2, nil)
+	return math.Ldexp(root.Root, k), err
+}
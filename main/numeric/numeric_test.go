@@ -0,0 +1,130 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func square(x float64) float64 { return x*x - 2 }
+
+func TestSolvers(t *testing.T) {
+	solvers := []struct {
+		name  string
+		solve func(f func(float64) float64, opts *Options) (Result, error)
+	}{
+		{"Newton", func(f func(float64) float64, opts *Options) (Result, error) {
+			return Newton(f, nil, 1, opts)
+		}},
+		{"Secant", func(f func(float64) float64, opts *Options) (Result, error) {
+			return Secant(f, 1, 2, opts)
+		}},
+		{"Bisection", func(f func(float64) float64, opts *Options) (Result, error) {
+			return Bisection(f, 0, 3, opts)
+		}},
+		{"Brent", func(f func(float64) float64, opts *Options) (Result, error) {
+			return Brent(f, 0, 3, opts)
+		}},
+	}
+	funcs := []struct {
+		name string
+		f    func(float64) float64
+		want float64
+	}{
+		{"x²-2", square, math.Sqrt(2)},
+		{"x³-5", func(x float64) float64 { return x*x*x - 5 }, math.Cbrt(5)},
+		{"cos(x)-x", func(x float64) float64 { return math.Cos(x) - x }, 0.7390851332151607},
+	}
+	for _, s := range solvers {
+		for _, f := range funcs {
+			r, err := s.solve(f.f, nil)
+			if err != nil {
+				t.Errorf("%s(%s): %v", s.name, f.name, err)
+				continue
+			}
+			if math.Abs(r.Root-f.want) > 1e-10 {
+				t.Errorf("%s(%s) = %v, want %v", s.name, f.name, r.Root, f.want)
+			}
+			if n := r.Iterations(); n == 0 || r.Trace[n-1].X != r.Root {
+				t.Errorf("%s(%s): trace %v does not end at the root %v", s.name, f.name, r.Trace, r.Root)
+			}
+		}
+	}
+}
+
+func TestIterations(t *testing.T) {
+	newton, _ := Newton(square, func(x float64) float64 { return 2 * x }, 1, nil)
+	secant, _ := Secant(square, 1, 2, nil)
+	bisection, _ := Bisection(square, 0, 3, nil)
+	brent, _ := Brent(square, 0, 3, nil)
+	if !(newton.Iterations() < bisection.Iterations() &&
+		secant.Iterations() < bisection.Iterations() &&
+		brent.Iterations() < bisection.Iterations()) {
+		t.Errorf("iterations: Newton %d, secant %d, Brent %d, want fewer than bisection %d",
+			newton.Iterations(), secant.Iterations(), brent.Iterations(), bisection.Iterations())
+	}
+	// Bisection halves the interval of width 3 at each iteration.
+	if n, want := bisection.Iterations(), int(math.Ceil(math.Log2(3/DefaultTolerance/math.Sqrt2))); n > want+1 {
+		t.Errorf("Bisection took %d iterations, want about %d", n, want)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	r, err := Bisection(square, 0, 3, &Options{Tolerance: 1e-3})
+	if err != nil || math.Abs(r.Root-math.Sqrt2) > 2e-3 {
+		t.Errorf("Bisection with tolerance 1e-3 = %v, %v", r.Root, err)
+	}
+	if loose, _ := Bisection(square, 0, 3, nil); r.Iterations() >= loose.Iterations() {
+		t.Errorf("Bisection took %d iterations with tolerance 1e-3, %d with the default", r.Iterations(), loose.Iterations())
+	}
+	// Until the estimate stops changing: to the nearest float, with
+	// the exact residual of math.FMA.
+	exact := func(x float64) float64 { return math.FMA(x, x, -2) }
+	r, err = Newton(exact, func(x float64) float64 { return 2 * x }, 1, &Options{Tolerance: -1})
+	if err != nil || r.Root != math.Sqrt2 {
+		t.Errorf("Newton with tolerance -1 = %v, %v, want %v", r.Root, err, math.Sqrt2)
+	}
+	r, err = Newton(square, nil, 1, &Options{MaxIter: 2})
+	if !errors.Is(err, ErrNoConvergence) || r.Iterations() != 2 {
+		t.Errorf("Newton with 2 iterations = %d iterations, %v, want 2, ErrNoConvergence", r.Iterations(), err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := Bisection(square, 2, 3, nil); !errors.Is(err, ErrNotBracketed) {
+		t.Errorf("Bisection(2, 3) error = %v, want ErrNotBracketed", err)
+	}
+	if _, err := Brent(square, -1, 1, nil); !errors.Is(err, ErrNotBracketed) {
+		t.Errorf("Brent(-1, 1) error = %v, want ErrNotBracketed", err)
+	}
+	// x²+1 has no real root.
+	if _, err := Newton(func(x float64) float64 { return x*x + 1 }, nil, 1, nil); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("Newton(x²+1) error = %v, want ErrNoConvergence", err)
+	}
+}
+
+func TestNthRoot(t *testing.T) {
+	for _, x := range []float64{0, 1e-300, 1e-9, 0.5, 1, 2, 81, 1000, 12345.678, 1e300} {
+		got, err := NthRoot(x, 2)
+		if want := math.Sqrt(x); err != nil || math.Abs(got-want) > 1e-14*want {
+			t.Errorf("NthRoot(%g, 2) = %v, %v, want %v", x, got, err, want)
+		}
+		for _, x := range []float64{x, -x} {
+			got, err := NthRoot(x, 3)
+			if want := math.Cbrt(x); err != nil || math.Abs(got-want) > 1e-14*math.Abs(want) {
+				t.Errorf("NthRoot(%g, 3) = %v, %v, want %v", x, got, err, want)
+			}
+		}
+	}
+	if got, err := NthRoot(1024, 10); err != nil || math.Abs(got-2) > 1e-14 {
+		t.Errorf("NthRoot(1024, 10) = %v, %v, want 2", got, err)
+	}
+	for _, tt := range []struct {
+		x float64
+		n int
+	}{{-4, 2}, {2, 0}, {math.NaN(), 3}} {
+		if _, err := NthRoot(tt.x, tt.n); !errors.Is(err, ErrDomain) {
+			t.Errorf("NthRoot(%v, %d) error = %v, want ErrDomain", tt.x, tt.n, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Package parallel runs the iterations of a loop on a pool of
+// goroutines: the worker pool rendering the tiles of the render
+// package and the frames of the animate package.
+package parallel
+
+import (
+	"runtime"
+	"sync"
+)
+
+// For calls f(i) for i from 0 to n-1 on up to workers goroutines,
+// runtime.NumCPU() if workers is zero or less, and returns when all
+// the calls have returned. The calls run in no particular order:
+// f must be safe for concurrent use, like writing to the i-th element
+// of a slice.
+func For(n, workers int, f func(i int)) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(n, workers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
@@ -0,0 +1,39 @@
+package parallel
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFor(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		const n = 50
+		var (
+			mu       sync.Mutex
+			calls    [n]int
+			running  int
+			greatest int
+		)
+		For(n, workers, func(i int) {
+			mu.Lock()
+			calls[i]++
+			running++
+			greatest = max(greatest, running)
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+		for i, c := range calls {
+			if c != 1 {
+				t.Errorf("For(%d, %d): f(%d) called %d times", n, workers, i, c)
+			}
+		}
+		if workers > 0 && greatest > workers {
+			t.Errorf("For(%d, %d): %d calls at once", n, workers, greatest)
+		}
+	}
+	For(0, 4, func(i int) { t.Errorf("For(0, 4) called f(%d)", i) })
+}
@@ -0,0 +1,156 @@
+// Package readers provides io.Reader building blocks, generalizing
+// the MyReader and rot13Reader exercises of the tour: endless
+// patterns, counters, and readers misbehaving on purpose, with short
+// reads, delays and errors, to test code reading from them.
+package readers
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"time"
+	"unicode"
+
+	"golearning/cipher"
+)
+
+type repeat struct {
+	pattern []byte
+	offset  int // offset in pattern of the next byte
+}
+
+// Repeat returns a reader repeating pattern forever, like MyReader
+// does with "A". It panics if pattern is empty.
+func Repeat(pattern string) io.Reader {
+	if pattern == "" {
+		panic("readers: empty pattern")
+	}
+	return &repeat{pattern: []byte(pattern)}
+}
+
+func (r *repeat) Read(p []byte) (int, error) {
+	for n := 0; n < len(p); {
+		c := copy(p[n:], r.pattern[r.offset:])
+		n += c
+		r.offset = (r.offset + c) % len(r.pattern)
+	}
+	return len(p), nil
+}
+
+// Counter is a reader counting the bytes read from R and the
+// Read calls made.
+type Counter struct {
+	R     io.Reader
+	Bytes int64
+	Calls int
+}
+
+func (c *Counter) Read(p []byte) (int, error) {
+	n, err := c.R.Read(p)
+	c.Bytes += int64(n)
+	c.Calls++
+	return n, err
+}
+
+// RateLimited is a reader reading from R no faster than Rate
+// bytes per second, sleeping when ahead. A single Read returns
+// at most Rate bytes.
+type RateLimited struct {
+	R    io.Reader
+	Rate int // bytes per second
+
+	// Now and Sleep default to time.Now and time.Sleep,
+	// tests replace them with a fake clock.
+	Now   func() time.Time
+	Sleep func(time.Duration)
+
+	start time.Time
+	read  int64
+}
+
+func (r *RateLimited) Read(p []byte) (int, error) {
+	if r.Rate <= 0 {
+		return 0, errors.New("readers: rate limit of zero")
+	}
+	now, sleep := r.Now, r.Sleep
+	if now == nil {
+		now = time.Now
+	}
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	if r.start.IsZero() {
+		r.start = now()
+	}
+	n, err := r.R.Read(p[:min(len(p), r.Rate)])
+	r.read += int64(n)
+	// Wait until the bytes read since start are allowed.
+	due := r.start.Add(time.Duration(r.read) * time.Second / time.Duration(r.Rate))
+	if wait := due.Sub(now()); wait > 0 {
+		sleep(wait)
+	}
+	return n, err
+}
+
+type chunking struct {
+	r    io.Reader
+	rnd  *rand.Rand
+	size int
+}
+
+// Chunking returns a reader returning short reads: each Read asks r
+// for a random number of bytes, from 1 to size, whatever the size of
+// the buffer. Drawing from rnd makes the reads reproducible.
+func Chunking(r io.Reader, rnd *rand.Rand, size int) io.Reader {
+	return &chunking{r, rnd, max(1, size)}
+}
+
+func (c *chunking) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return c.r.Read(p[:min(len(p), 1+c.rnd.Intn(c.size))])
+}
+
+// ErrInjected is the error returned by FailAfter by default.
+var ErrInjected = errors.New("readers: injected error")
+
+type failing struct {
+	r   io.Reader
+	n   int64 // bytes left before the error
+	err error
+}
+
+// FailAfter returns a reader reading the first n bytes of r, then
+// returning err, ErrInjected if nil, instead of the rest.
+func FailAfter(r io.Reader, n int64, err error) io.Reader {
+	if err == nil {
+		err = ErrInjected
+	}
+	return &failing{r, n, err}
+}
+
+func (f *failing) Read(p []byte) (int, error) {
+	if f.n <= 0 {
+		return 0, f.err
+	}
+	n, err := f.r.Read(p[:min(int64(len(p)), f.n)])
+	f.n -= int64(n)
+	return n, err
+}
+
+// Map returns a reader mapping each rune of the UTF-8 text read
+// from r with f.
+func Map(r io.Reader, f func(rune) rune) io.Reader {
+	return cipher.NewReader(r, f)
+}
+
+// Upper returns a reader turning the text read from r to upper case.
+func Upper(r io.Reader) io.Reader {
+	return Map(r, unicode.ToUpper)
+}
+
+// Lower returns a reader turning the text read from r to lower case.
+func Lower(r io.Reader) io.Reader {
+	return Map(r, unicode.ToLower)
+}
@@ -0,0 +1,116 @@
+package readers
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestRepeat(t *testing.T) {
+	r := Repeat("abc")
+	for _, tt := range []struct {
+		size int
+		want string
+	}{{2, "ab"}, {5, "cabca"}, {0, ""}, {7, "bcabcab"}} {
+		b := make([]byte, tt.size)
+		if n, err := r.Read(b); n != tt.size || err != nil || string(b) != tt.want {
+			t.Errorf("Read(%d) = %d, %v, %q, want %q", tt.size, n, err, b, tt.want)
+		}
+	}
+	got, _ := io.ReadAll(io.LimitReader(Repeat("A"), 5))
+	if string(got) != "AAAAA" {
+		t.Errorf("Repeat(A) read %q", got)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := &Counter{R: strings.NewReader("Hello, Reader!")}
+	b := make([]byte, 8)
+	for {
+		if _, err := c.Read(b); err != nil {
+			break
+		}
+	}
+	if c.Bytes != 14 || c.Calls != 3 {
+		t.Errorf("Bytes, Calls = %d, %d, want 14, 3", c.Bytes, c.Calls)
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	now := time.Date(2024, 3, 9, 10, 0, 0, 0, time.UTC)
+	var slept time.Duration
+	r := &RateLimited{
+		R:     strings.NewReader(strings.Repeat("x", 250)),
+		Rate:  100,
+		Now:   func() time.Time { return now },
+		Sleep: func(d time.Duration) { slept += d; now = now.Add(d) },
+	}
+	b := make([]byte, 1000)
+	var sizes []int
+	for {
+		n, err := r.Read(b)
+		if err != nil {
+			break
+		}
+		sizes = append(sizes, n)
+	}
+	if len(sizes) != 3 || sizes[0] != 100 || sizes[2] != 50 {
+		t.Errorf("read sizes %v, want at most 100 bytes per read", sizes)
+	}
+	if slept != 2500*time.Millisecond {
+		t.Errorf("slept %v reading 250 bytes at 100 B/s, want 2.5s", slept)
+	}
+}
+
+func TestChunking(t *testing.T) {
+	const text = "Hello, Reader! Hello again."
+	r := Chunking(strings.NewReader(text), rand.New(rand.NewSource(1)), 4)
+	b := make([]byte, 100)
+	var got []byte
+	for {
+		n, err := r.Read(b)
+		if n > 4 {
+			t.Errorf("Read returned %d bytes, want at most 4", n)
+		}
+		got = append(got, b[:n]...)
+		if err != nil {
+			break
+		}
+	}
+	if string(got) != text {
+		t.Errorf("read %q, want %q", got, text)
+	}
+	if err := iotest.TestReader(Chunking(strings.NewReader(text), rand.New(rand.NewSource(2)), 3), []byte(text)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFailAfter(t *testing.T) {
+	got, err := io.ReadAll(FailAfter(strings.NewReader("Hello, Reader!"), 5, nil))
+	if string(got) != "Hello" || !errors.Is(err, ErrInjected) {
+		t.Errorf("read %q, %v, want %q, ErrInjected", got, err, "Hello")
+	}
+	got, err = io.ReadAll(FailAfter(strings.NewReader("Hi"), 5, io.ErrUnexpectedEOF))
+	if string(got) != "Hi" || err != nil {
+		t.Errorf("short input: read %q, %v, want all of it", got, err)
+	}
+	_, err = io.ReadAll(FailAfter(Repeat("A"), 0, io.ErrUnexpectedEOF))
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("error = %v, want the injected one", err)
+	}
+}
+
+func TestCase(t *testing.T) {
+	got, _ := io.ReadAll(Upper(iotest.OneByteReader(strings.NewReader("Hello, café ß"))))
+	if string(got) != "HELLO, CAFÉ ß" {
+		t.Errorf("Upper read %q", got)
+	}
+	got, _ = io.ReadAll(Lower(strings.NewReader("HELLO, ÇA VA")))
+	if string(got) != "hello, ça va" {
+		t.Errorf("Lower read %q", got)
+	}
+}
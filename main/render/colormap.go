@@ -0,0 +1,78 @@
+package render
+
+import (
+	"image/color"
+	"math"
+	"sort"
+)
+
+// A Colormap gives the color of a level, from 0 to 255.
+type Colormap func(v uint8) color.RGBA
+
+// Tour is the colormap of pic.Show: shades of blue, from blue to white.
+func Tour(v uint8) color.RGBA {
+	return color.RGBA{v, v, 255, 255}
+}
+
+// Gray maps the levels to shades of gray, from black to white.
+func Gray(v uint8) color.RGBA {
+	return color.RGBA{v, v, v, 255}
+}
+
+// Heat goes from black to white through red and yellow.
+func Heat(v uint8) color.RGBA {
+	t := float64(v) / 255
+	return color.RGBA{unit(3 * t), unit(3*t - 1), unit(3*t - 2), 255}
+}
+
+// Rainbow goes around the hues, from red back to red.
+func Rainbow(v uint8) color.RGBA {
+	h := float64(v) / 256 * 6
+	x := 1 - math.Abs(math.Mod(h, 2)-1)
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g = 1, x
+	case 1:
+		r, g = x, 1
+	case 2:
+		g, b = 1, x
+	case 3:
+		g, b = x, 1
+	case 4:
+		r, b = x, 1
+	default:
+		r, b = 1, x
+	}
+	return color.RGBA{unit(r), unit(g), unit(b), 255}
+}
+
+// Ocean goes from a deep blue to white through cyan.
+func Ocean(v uint8) color.RGBA {
+	t := float64(v) / 255
+	return color.RGBA{unit(2*t - 1), unit(t), unit(0.3 + 0.7*t), 255}
+}
+
+// unit converts t, clamped to [0, 1], to a color component.
+func unit(t float64) uint8 {
+	return uint8(math.Round(255 * math.Max(0, math.Min(1, t))))
+}
+
+// Colormaps are the colormaps by name.
+var Colormaps = map[string]Colormap{
+	"tour":    Tour,
+	"gray":    Gray,
+	"heat":    Heat,
+	"rainbow": Rainbow,
+	"ocean":   Ocean,
+}
+
+// ColormapNames returns the names of Colormaps, sorted.
+func ColormapNames() []string {
+	names := make([]string, 0, len(Colormaps))
+	for name := range Colormaps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
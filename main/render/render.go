@@ -0,0 +1,123 @@
+// Package render turns the pictures of the tour exercises into image
+// files: it draws Pic functions with a colormap, and encodes images
+// as PNG, GIF or JPEG, where the tour could only show them on its
+// website through golang.org/x/tour/pic.
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Pic is the signature of the function of the slices exercise:
+// it returns dy rows of dx levels, from 0 to 255.
+type Pic func(dx, dy int) [][]uint8
+
+// FromPic returns the image drawn by pic at the size dx×dy, its
+// levels colored by cm, or in shades of blue like pic.Show does
+// if cm is nil. Rows or levels missing from the result of pic are
+// left transparent.
+func FromPic(pic Pic, dx, dy int, cm Colormap) *image.RGBA {
+	if cm == nil {
+		cm = Tour
+	}
+	m := image.NewRGBA(image.Rect(0, 0, dx, dy))
+	for y, row := range pic(dx, dy) {
+		if y >= dy {
+			break
+		}
+		for x, v := range row[:min(len(row), dx)] {
+			m.SetRGBA(x, y, cm(v))
+		}
+	}
+	return m
+}
+
+// Recolor returns a copy of m where the luminance of each pixel,
+// from 0 to 255, is colored by cm.
+func Recolor(m image.Image, cm Colormap) *image.RGBA {
+	b := m.Bounds()
+	dst := image.NewRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			gray := color.GrayModel.Convert(m.At(x, y)).(color.Gray)
+			dst.SetRGBA(x, y, cm(gray.Y))
+		}
+	}
+	return dst
+}
+
+// Resize returns a copy of m scaled to w×h, each pixel taking
+// the color of the nearest pixel of m.
+func Resize(m image.Image, w, h int) *image.RGBA {
+	b := m.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	if b.Empty() {
+		return dst
+	}
+	for y := 0; y < h; y++ {
+		sy := b.Min.Y + y*b.Dy()/h
+		for x := 0; x < w; x++ {
+			dst.Set(x, y, m.At(b.Min.X+x*b.Dx()/w, sy))
+		}
+	}
+	return dst
+}
+
+// Formats are the names of the image formats Encode supports.
+var Formats = []string{"png", "gif", "jpeg"}
+
+// FormatOf returns the format of the image file name, from its extension.
+func FormatOf(name string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".png":
+		return "png", nil
+	case ".gif":
+		return "gif", nil
+	case ".jpg", ".jpeg":
+		return "jpeg", nil
+	default:
+		return "", fmt.Errorf("render: unknown image extension %q", ext)
+	}
+}
+
+// Encode writes m to w in format, one of Formats. GIF images are
+// limited to 256 colors: m is dithered to the Plan 9 palette,
+// unless it is already paletted.
+func Encode(w io.Writer, m image.Image, format string) error {
+	switch format {
+	case "png":
+		return png.Encode(w, m)
+	case "gif":
+		return gif.Encode(w, m, &gif.Options{NumColors: 256, Drawer: draw.FloydSteinberg})
+	case "jpeg", "jpg":
+		return jpeg.Encode(w, m, &jpeg.Options{Quality: 90})
+	}
+	return fmt.Errorf("render: unknown image format %q", format)
+}
+
+// WriteFile writes m to the file name, in the format of its extension.
+func WriteFile(name string, m image.Image) error {
+	format, err := FormatOf(name)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := Encode(f, m, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func product(dx, dy int) [][]uint8 {
+	rows := make([][]uint8, dy)
+	for y := range rows {
+		rows[y] = make([]uint8, dx)
+		for x := range rows[y] {
+			rows[y][x] = uint8(x * y)
+		}
+	}
+	return rows
+}
+
+func TestFromPic(t *testing.T) {
+	m := FromPic(product, 16, 8, nil)
+	if got := m.Bounds(); got != image.Rect(0, 0, 16, 8) {
+		t.Errorf("Bounds() = %v", got)
+	}
+	if got, want := m.RGBAAt(5, 3), (color.RGBA{15, 15, 255, 255}); got != want {
+		t.Errorf("At(5, 3) = %v, want %v", got, want)
+	}
+	m = FromPic(product, 4, 4, Gray)
+	if got, want := m.RGBAAt(3, 3), (color.RGBA{9, 9, 9, 255}); got != want {
+		t.Errorf("gray At(3, 3) = %v, want %v", got, want)
+	}
+	// A Pic returning too little leaves the pixels transparent,
+	// and one returning too much is cut.
+	short := func(dx, dy int) [][]uint8 { return [][]uint8{{1, 2, 3, 4, 5, 6}} }
+	m = FromPic(short, 4, 2, Gray)
+	if m.RGBAAt(3, 0).A != 255 || m.RGBAAt(0, 1).A != 0 {
+		t.Errorf("short Pic: %v", m.Pix)
+	}
+}
+
+func TestColormaps(t *testing.T) {
+	for _, name := range ColormapNames() {
+		cm := Colormaps[name]
+		if c := cm(0); c.A != 255 {
+			t.Errorf("%s(0) = %v, not opaque", name, c)
+		}
+	}
+	if Heat(0) != (color.RGBA{0, 0, 0, 255}) || Heat(255) != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("Heat goes from %v to %v, want black to white", Heat(0), Heat(255))
+	}
+	if Rainbow(0) != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("Rainbow(0) = %v, want red", Rainbow(0))
+	}
+}
+
+func TestRecolorResize(t *testing.T) {
+	m := FromPic(product, 8, 8, Gray)
+	if got := Recolor(m, Tour).RGBAAt(2, 3); got != Tour(6) {
+		t.Errorf("Recolor At(2, 3) = %v, want %v", got, Tour(6))
+	}
+	big := Resize(m, 16, 32)
+	if big.Bounds() != image.Rect(0, 0, 16, 32) || big.RGBAAt(5, 13) != m.RGBAAt(2, 3) {
+		t.Errorf("Resize At(5, 13) = %v, want %v", big.RGBAAt(5, 13), m.RGBAAt(2, 3))
+	}
+}
+
+func TestEncode(t *testing.T) {
+	m := FromPic(product, 32, 16, Rainbow)
+	for _, format := range Formats {
+		var b bytes.Buffer
+		if err := Encode(&b, m, format); err != nil {
+			t.Errorf("Encode(%s): %v", format, err)
+			continue
+		}
+		decoded, got, err := image.Decode(&b)
+		if err != nil || got != format || decoded.Bounds() != m.Bounds() {
+			t.Errorf("Encode(%s) decoded as %s %v, %v", format, got, decoded.Bounds(), err)
+		}
+	}
+	if err := Encode(&bytes.Buffer{}, m, "bmp"); err == nil {
+		t.Error("Encode(bmp) returned no error")
+	}
+	// PNG is lossless.
+	var b bytes.Buffer
+	Encode(&b, m, "png")
+	decoded, _, _ := image.Decode(&b)
+	if got := decoded.At(7, 5); color.RGBAModel.Convert(got) != m.At(7, 5) {
+		t.Errorf("PNG At(7, 5) = %v, want %v", got, m.At(7, 5))
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	for name, want := range map[string]string{"a.png": "png", "b.GIF": "gif", "c.jpg": "jpeg"} {
+		name = filepath.Join(dir, name)
+		if err := WriteFile(name, FromPic(product, 8, 8, nil)); err != nil {
+			t.Fatal(err)
+		}
+		f, _ := os.Open(name)
+		_, format, err := image.DecodeConfig(f)
+		f.Close()
+		if err != nil || format != want {
+			t.Errorf("%s written as %s, %v", name, format, err)
+		}
+	}
+	if err := WriteFile(filepath.Join(dir, "d.txt"), FromPic(product, 8, 8, nil)); err == nil {
+		t.Error("WriteFile(d.txt) returned no error")
+	}
+}
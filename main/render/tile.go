@@ -0,0 +1,50 @@
+package render
+
+import (
+	"image"
+	"image/draw"
+
+	"golearning/parallel"
+)
+
+// Serial returns a copy of m, asking m the color of each pixel
+// in turn, on the calling goroutine.
+func Serial(m image.Image) *image.RGBA {
+	dst := image.NewRGBA(m.Bounds())
+	draw.Draw(dst, dst.Bounds(), m, dst.Bounds().Min, draw.Src)
+	return dst
+}
+
+// Tiles splits r into tiles of size×size pixels, row by row,
+// the tiles of the last row and column being smaller when size
+// does not divide the size of r.
+func Tiles(r image.Rectangle, size int) []image.Rectangle {
+	size = max(1, size)
+	var tiles []image.Rectangle
+	for y := r.Min.Y; y < r.Max.Y; y += size {
+		for x := r.Min.X; x < r.Max.X; x += size {
+			tiles = append(tiles, image.Rect(x, y, x+size, y+size).Intersect(r))
+		}
+	}
+	return tiles
+}
+
+// Tiled returns a copy of m, like Serial, but computed in parallel:
+// the bounds of m are split into tiles of size×size pixels, drawn by
+// a pool of workers goroutines, runtime.NumCPU() if workers is zero
+// or less. The result is the same as the one of Serial, as long as
+// m.At is safe to call concurrently.
+//
+// Small tiles balance the work better, when some parts of m are
+// slower to compute than others, like the inside of the Mandelbrot
+// set; large tiles cost less to hand out.
+func Tiled(m image.Image, size, workers int) *image.RGBA {
+	dst := image.NewRGBA(m.Bounds())
+	tiles := Tiles(m.Bounds(), size)
+	parallel.For(len(tiles), workers, func(i int) {
+		// The tiles do not overlap: each worker
+		// writes its own pixels of dst.
+		draw.Draw(dst, tiles[i], m, tiles[i].Min, draw.Src)
+	})
+	return dst
+}
@@ -0,0 +1,84 @@
+package render_test
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"golearning/gallery"
+	"golearning/render"
+)
+
+func TestTiles(t *testing.T) {
+	r := image.Rect(-3, 2, 7, 9) // 10×7
+	tiles := render.Tiles(r, 4)
+	if len(tiles) != 3*2 {
+		t.Fatalf("%d tiles, want 6: %v", len(tiles), tiles)
+	}
+	// The tiles cover r, without overlapping.
+	area := 0
+	for i, a := range tiles {
+		if !a.In(r) || a.Empty() {
+			t.Errorf("tile %v out of %v", a, r)
+		}
+		area += a.Dx() * a.Dy()
+		for _, b := range tiles[i+1:] {
+			if a.Overlaps(b) {
+				t.Errorf("tiles %v and %v overlap", a, b)
+			}
+		}
+	}
+	if area != 70 {
+		t.Errorf("the tiles cover %d pixels, want 70", area)
+	}
+	if got := render.Tiles(image.Rectangle{}, 4); len(got) != 0 {
+		t.Errorf("Tiles(empty) = %v", got)
+	}
+}
+
+// offset is an image whose bounds do not start at (0, 0).
+type offset struct{ image.Image }
+
+func (o offset) Bounds() image.Rectangle { return o.Image.Bounds().Add(image.Pt(-17, 5)) }
+func (o offset) At(x, y int) color.Color { return o.Image.At(x+17, y-5) }
+
+// TestTiledIsSerial checks that the parallel rendering gives the
+// image the serial one gives, whatever the tiles and the workers.
+// Run it with -race.
+func TestTiledIsSerial(t *testing.T) {
+	c := gallery.Canvas{Width: 101, Height: 67}
+	images := map[string]image.Image{
+		"mandelbrot": gallery.Mandelbrot{Canvas: c},
+		"perlin":     gallery.NewPerlin(c, 3),
+		"offset":     offset{gallery.Julia{Canvas: c}},
+	}
+	for name, m := range images {
+		want := render.Serial(m)
+		for _, size := range []int{1, 7, 32, 200} {
+			for _, workers := range []int{0, 1, 3} {
+				if got := render.Tiled(m, size, workers); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: tiles of %d on %d workers differ from the serial rendering", name, size, workers)
+				}
+			}
+		}
+	}
+}
+
+var mandelbrot = gallery.Mandelbrot{Canvas: gallery.Canvas{Width: 1024, Height: 1024}}
+
+func BenchmarkMandelbrot(b *testing.B) {
+	b.Run("Serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			render.Serial(mandelbrot)
+		}
+	})
+	for _, size := range []int{16, 64, 256} {
+		b.Run(fmt.Sprintf("Tiled%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				render.Tiled(mandelbrot, size, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package sliceviz
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// Growth is what an append did to a slice.
+type Growth struct {
+	Len, Cap int // after the append
+	OldCap   int
+	// Realloc is true if the append moved the slice to a new array,
+	// copying Copied elements there, the old one being too small.
+	Realloc bool
+	Copied  int
+}
+
+// Factor returns the factor by which the capacity grew.
+func (g Growth) Factor() float64 {
+	if g.OldCap == 0 {
+		return 0
+	}
+	return float64(g.Cap) / float64(g.OldCap)
+}
+
+func (g Growth) String() string {
+	s := fmt.Sprintf("len %d, cap %d", g.Len, g.Cap)
+	switch {
+	case !g.Realloc:
+		return s
+	case g.OldCap == 0:
+		return s + ": new array"
+	}
+	return fmt.Sprintf("%s: new array, %d copied, cap %d -> %d (x%.2f)", s, g.Copied, g.OldCap, g.Cap, g.Factor())
+}
+
+// Append appends the values to s one at a time, as many appends, and
+// returns the slice and what each append did.
+func Append[T any](s []T, values ...T) ([]T, []Growth) {
+	trace := make([]Growth, 0, len(values))
+	for _, v := range values {
+		before, oldCap, n := unsafe.SliceData(s), cap(s), len(s)
+		s = append(s, v)
+		realloc := unsafe.SliceData(s) != before || cap(s) != oldCap
+		g := Growth{Len: len(s), Cap: cap(s), OldCap: oldCap, Realloc: realloc}
+		if realloc {
+			g.Copied = n
+		}
+		trace = append(trace, g)
+	}
+	return s, trace
+}
+
+// Grow appends n zero values to s one at a time, and returns what
+// the appends reallocating s did: the capacities of its arrays,
+// doubling for small slices, then growing by less and less, down to
+// 1.25 times for large ones, rounded up to the sizes of allocations.
+func Grow[T any](s []T, n int) []Growth {
+	_, trace := Append(s, make([]T, n)...)
+	var reallocs []Growth
+	for _, g := range trace {
+		if g.Realloc {
+			reallocs = append(reallocs, g)
+		}
+	}
+	return reallocs
+}
+
+// FprintGrowth writes the growths to w, one per line.
+func FprintGrowth(w io.Writer, trace []Growth) {
+	for _, g := range trace {
+		fmt.Fprintf(w, "append: %v\n", g)
+	}
+}
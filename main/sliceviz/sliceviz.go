@@ -0,0 +1,180 @@
+// Package sliceviz draws what slices are made of: the arrays behind
+// them, the window of each slice on its array, from its offset over
+// its length and up to its capacity, and the slices sharing an array,
+// which see each other's writes. It also traces the reallocations of
+// append, as the slice outgrows its array.
+package sliceviz
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unsafe"
+)
+
+// Slice is a slice to draw, and its name.
+type Slice[T any] struct {
+	Name string
+	S    []T
+}
+
+// Named returns the slice s named name.
+func Named[T any](name string, s []T) Slice[T] {
+	return Slice[T]{name, s}
+}
+
+// View is the window of a slice on its array.
+type View struct {
+	Name             string
+	Offset, Len, Cap int
+}
+
+// Array is an array behind slices: its elements, from the first one
+// in a slice to its end, and the views of the slices on it.
+type Array[T any] struct {
+	Elems []T
+	Views []View
+}
+
+// Aliases reports whether several slices share the array.
+func (a Array[T]) Aliases() bool { return len(a.Views) > 1 }
+
+// Arrays returns the arrays behind the slices, in the order of the
+// slices, and the names of the nil slices and of those of capacity
+// 0, which have no array. The views on an array are in the order of
+// the slices.
+func Arrays[T any](slices ...Slice[T]) (arrays []Array[T], empty []string) {
+	// The addresses of the first elements of the slices, and of the
+	// ends of their arrays: slices of the same array end at the same
+	// address, the capacity of a slice reaching the end of its array.
+	// They are taken at once, without calls which could grow the
+	// stack and move the arrays on it.
+	size := unsafe.Sizeof(*new(T))
+	starts, ends := make([]uintptr, len(slices)), make([]uintptr, len(slices))
+	for i := range slices {
+		starts[i] = uintptr(unsafe.Pointer(unsafe.SliceData(slices[i].S)))
+		ends[i] = starts[i] + uintptr(cap(slices[i].S))*size
+	}
+
+	// The first slice of each array, the one starting the earliest.
+	first := make(map[uintptr]int)
+	var order []uintptr
+	for i, s := range slices {
+		if cap(s.S) == 0 {
+			empty = append(empty, s.Name)
+			continue
+		}
+		f, ok := first[ends[i]]
+		if !ok {
+			order = append(order, ends[i])
+		}
+		if !ok || starts[i] < starts[f] {
+			first[ends[i]] = i
+		}
+	}
+	for _, end := range order {
+		f := first[end]
+		a := Array[T]{Elems: slices[f].S[:cap(slices[f].S)]}
+		for i, s := range slices {
+			if cap(s.S) > 0 && ends[i] == end {
+				offset := 0
+				if size > 0 { // elements of size 0 are all at the same address
+					offset = int((starts[i] - starts[f]) / size)
+				}
+				a.Views = append(a.Views, View{s.Name, offset, len(s.S), cap(s.S)})
+			}
+		}
+		arrays = append(arrays, a)
+	}
+	return arrays, empty
+}
+
+// Fprint draws the arrays behind the slices to w: the elements of
+// each array in boxes, under their indices, then one line per slice,
+// its window from [ to ], with = over its length and . over the rest
+// of its capacity:
+//
+//	array 1: [4]string, shared by names, a and b
+//	         0      1      2        3
+//	       +------+------+--------+-------+
+//	       | John | Paul | George | Ringo |
+//	       +------+------+--------+-------+
+//	names  [==============================]  offset 0, len 4, cap 4
+//	a      [=============)................]  offset 0, len 2, cap 4
+//	b             [===============).......]  offset 1, len 2, cap 3
+func Fprint[T any](w io.Writer, slices ...Slice[T]) {
+	arrays, empty := Arrays(slices...)
+	width := 0
+	for _, s := range slices {
+		width = max(width, len(s.Name))
+	}
+	for i, a := range arrays {
+		names := make([]string, len(a.Views))
+		for j, v := range a.Views {
+			names[j] = v.Name
+		}
+		fmt.Fprintf(w, "array %d: [%d]%T", i+1, len(a.Elems), *new(T))
+		if a.Aliases() {
+			fmt.Fprintf(w, ", shared by %s", join(names))
+		}
+		fmt.Fprintln(w)
+
+		// The columns of the borders of the boxes.
+		cells := make([]string, len(a.Elems))
+		columns := []int{width + 2}
+		for j, e := range a.Elems {
+			cells[j] = fmt.Sprint(e)
+			columns = append(columns, columns[j]+max(len(cells[j]), len(fmt.Sprint(j)))+3)
+		}
+		margin := strings.Repeat(" ", width+2)
+		var indices, border, values strings.Builder
+		for j, cell := range cells {
+			w := columns[j+1] - columns[j] - 3
+			fmt.Fprintf(&indices, "  %-*d ", w, j)
+			fmt.Fprintf(&border, "+%s", strings.Repeat("-", w+2))
+			fmt.Fprintf(&values, "| %-*s ", w, cell)
+		}
+		fmt.Fprintln(w, strings.TrimRight(margin+indices.String(), " "))
+		fmt.Fprintln(w, margin+border.String()+"+")
+		fmt.Fprintln(w, margin+values.String()+"|")
+		fmt.Fprintln(w, margin+border.String()+"+")
+		for _, v := range a.Views {
+			fmt.Fprintf(w, "%-*s%s  offset %d, len %d, cap %d\n", width+2, v.Name,
+				window(columns, v)[width+2:], v.Offset, v.Len, v.Cap)
+		}
+	}
+	for _, name := range empty {
+		fmt.Fprintf(w, "%s: no array\n", name)
+	}
+}
+
+// window returns the window of v, drawn between the borders of the
+// boxes at columns.
+func window(columns []int, v View) string {
+	begin, len, end := columns[v.Offset], columns[v.Offset+v.Len], columns[v.Offset+v.Cap]
+	line := []byte(strings.Repeat(" ", end+1))
+	for c := begin; c <= end; c++ {
+		switch {
+		case c == begin:
+			line[c] = '['
+		case c == end:
+			line[c] = ']'
+		case c == len:
+			line[c] = ')'
+		case c < len:
+			line[c] = '='
+		default:
+			line[c] = '.'
+		}
+	}
+	return string(line)
+}
+
+// join returns the names separated by commas, and "and" before the
+// last one.
+func join(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
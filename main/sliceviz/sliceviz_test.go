@@ -0,0 +1,112 @@
+package sliceviz
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArrays(t *testing.T) {
+	names := [4]string{"John", "Paul", "George", "Ringo"}
+	a, b := names[0:2], names[1:3]
+	other := []string{"x", "y"}
+	arrays, empty := Arrays(
+		Named("b", b), Named("other", other), Named("a", a),
+		Named("nil", []string(nil)), Named("empty", []string{}), Named("tail", other[1:]),
+	)
+	want := []Array[string]{
+		{Elems: names[:], Views: []View{{"b", 1, 2, 3}, {"a", 0, 2, 4}}},
+		{Elems: other, Views: []View{{"other", 0, 2, 2}, {"tail", 1, 1, 1}}},
+	}
+	if !reflect.DeepEqual(arrays, want) {
+		t.Errorf("Arrays = %+v, want %+v", arrays, want)
+	}
+	if !reflect.DeepEqual(empty, []string{"nil", "empty"}) {
+		t.Errorf("Arrays: empty = %v", empty)
+	}
+
+	// A write through b is a write to the array of a.
+	b[0] = "XXX"
+	if arrays[0].Elems[1] != "XXX" || a[1] != "XXX" {
+		t.Errorf("after b[0] = XXX: array %v, a %v", arrays[0].Elems, a)
+	}
+
+	// A copy has its own array.
+	c := append([]string(nil), a...)
+	if arrays, _ := Arrays(Named("a", a), Named("c", c)); len(arrays) != 2 || arrays[0].Aliases() {
+		t.Errorf("Arrays(a, copy of a) = %+v", arrays)
+	}
+	if arrays, _ := Arrays(Named("z", make([]struct{}, 3)[1:])); arrays[0].Views[0] != (View{"z", 0, 2, 2}) {
+		t.Errorf("Arrays(zero size) = %+v", arrays)
+	}
+}
+
+func TestFprint(t *testing.T) {
+	names := [4]string{"John", "Paul", "George", "Ringo"}
+	var b strings.Builder
+	Fprint(&b, Named("names", names[:]), Named("a", names[0:2]), Named("b", names[1:3]))
+	if got, want := b.String(), `array 1: [4]string, shared by names, a and b
+         0      1      2        3
+       +------+------+--------+-------+
+       | John | Paul | George | Ringo |
+       +------+------+--------+-------+
+names  [==============================]  offset 0, len 4, cap 4
+a      [=============)................]  offset 0, len 2, cap 4
+b             [===============).......]  offset 1, len 2, cap 3
+`; got != want {
+		t.Errorf("Fprint =\n%s\nwant\n%s", got, want)
+	}
+
+	// The capacity of a full slice expression ends before the array.
+	primes := []int{2, 3, 5, 7, 11, 13}
+	b.Reset()
+	Fprint(&b, Named("primes[1:3:4]", primes[1:3:4]), Named("s", []int{}), Named("nil", []int(nil)))
+	if got, want := b.String(), `array 1: [3]int
+                 0   1   2
+               +---+---+---+
+               | 3 | 5 | 7 |
+               +---+---+---+
+primes[1:3:4]  [=======)...]  offset 0, len 2, cap 3
+s: no array
+nil: no array
+`; got != want {
+		t.Errorf("Fprint =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	s := make([]int, 0, 2)
+	s, trace := Append(s, 1, 2, 3)
+	if !reflect.DeepEqual(s, []int{1, 2, 3}) {
+		t.Errorf("Append = %v", s)
+	}
+	if trace[0].Realloc || trace[1].Realloc || !trace[2].Realloc || trace[2].Copied != 2 || trace[2].OldCap != 2 {
+		t.Errorf("Append: trace %+v", trace)
+	}
+	// The capacities are up to the runtime: check their properties,
+	// not their values.
+	c := trace[2].Cap
+	if got, want := trace[2].String(), fmt.Sprintf("len 3, cap %d: new array, 2 copied, cap 2 -> %d (x%.2f)", c, c, float64(c)/2); got != want {
+		t.Errorf("Growth = %q, want %q", got, want)
+	}
+	if got, want := trace[1].String(), "len 2, cap 2"; got != want {
+		t.Errorf("Growth = %q, want %q", got, want)
+	}
+
+	_, trace = Append([]int(nil), make([]int, 100000)...)
+	for _, g := range trace {
+		if g.Cap < g.Len || g.Cap != g.OldCap && !g.Realloc {
+			t.Fatalf("Append: %v", g)
+		}
+	}
+	reallocs := Grow([]int(nil), 100000)
+	if len(reallocs) == 0 || reallocs[0].OldCap != 0 || !strings.HasSuffix(reallocs[0].String(), ": new array") {
+		t.Fatalf("Grow: first %v", reallocs)
+	}
+	for _, g := range reallocs[1:] {
+		if f := g.Factor(); f <= 1 || f > 2 || g.Copied != g.OldCap {
+			t.Errorf("Grow: %v", g)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package spatial
+
+import (
+	"strings"
+
+	"golearning/errs"
+	"golearning/geo"
+)
+
+// The digits of geohashes, the base 32 without a, i, l and o.
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxPrecision is the largest number of characters of a geohash.
+const MaxPrecision = 12
+
+// bits returns the number of bits of the longitude and of the
+// latitude in a geohash of precision characters, of 5 bits each:
+// the bits alternate, starting with the longitude.
+func bits(precision int) (long, lat int) {
+	return (5*precision + 1) / 2, 5 * precision / 2
+}
+
+// cell returns the row and column of p in the grid of geohashes of
+// precision characters.
+func cell(p geo.Point, precision int) (row, col int) {
+	longBits, latBits := bits(precision)
+	return index(p.Lat, 90, latBits), index(p.Long, 180, longBits)
+}
+
+// index returns the index of x in [-r, r] cut in 2**n equal parts;
+// r itself is in the last one.
+func index(x, r float64, n int) int {
+	i := int((x + r) / (2 * r) * float64(int(1)<<n))
+	return min(max(i, 0), 1<<n-1)
+}
+
+// Geohash returns the geohash of p: the name of the cell of p in a
+// grid whose cells get 32 times smaller at each character, up to
+// MaxPrecision. Nearby points share a prefix, except across the
+// lines between cells.
+func Geohash(p geo.Point, precision int) string {
+	precision = min(max(precision, 1), MaxPrecision)
+	row, col := cell(p, precision)
+	return hash(row, col, precision)
+}
+
+// hash returns the geohash of the cell of the row and column.
+func hash(row, col, precision int) string {
+	longBits, latBits := bits(precision)
+	h := make([]byte, precision)
+	c := 0
+	for i := 0; i < 5*precision; i++ {
+		if i%2 == 0 {
+			longBits--
+			c = c<<1 | col>>longBits&1
+		} else {
+			latBits--
+			c = c<<1 | row>>latBits&1
+		}
+		if i%5 == 4 {
+			h[i/5], c = base32[c], 0
+		}
+	}
+	return string(h)
+}
+
+// DecodeGeohash returns the cell of a geohash.
+func DecodeGeohash(h string) (geo.Box, error) {
+	if len(h) == 0 || len(h) > MaxPrecision {
+		return geo.Box{}, errs.Errorf(errs.Invalid, "geohash %q: want 1 to %d characters", h, MaxPrecision)
+	}
+	row, col, rows, cols := 0, 0, 1, 1
+	for i := 0; i < 5*len(h); i++ {
+		c := strings.IndexByte(base32, h[i/5])
+		if c < 0 {
+			return geo.Box{}, errs.Errorf(errs.Invalid, "geohash %q: invalid character %q", h, h[i/5])
+		}
+		bit := c >> (4 - i%5) & 1
+		if i%2 == 0 {
+			col, cols = col<<1|bit, cols<<1
+		} else {
+			row, rows = row<<1|bit, rows<<1
+		}
+	}
+	height, width := 180/float64(rows), 360/float64(cols)
+	return geo.Box{
+		Min: geo.Point{Lat: -90 + float64(row)*height, Long: -180 + float64(col)*width},
+		Max: geo.Point{Lat: -90 + float64(row+1)*height, Long: -180 + float64(col+1)*width},
+	}, nil
+}
@@ -0,0 +1,98 @@
+package spatial
+
+import (
+	"math"
+
+	"golearning/geo"
+)
+
+// DefaultPrecision is the precision of the geohashes of a Grid of
+// precision 0: cells of about 5 km by 5 km, at most.
+const DefaultPrecision = 5
+
+// Grid is a grid of places by their geohash: a query only looks at
+// the cells of the box around its radius.
+type Grid struct {
+	precision int
+	cells     map[string][]geo.Place
+	n         int
+}
+
+// NewGrid returns the grid of the places, in cells of geohashes of
+// precision characters, DefaultPrecision if 0.
+func NewGrid(places map[string]geo.Point, precision int) *Grid {
+	if precision <= 0 {
+		precision = DefaultPrecision
+	}
+	g := &Grid{min(precision, MaxPrecision), make(map[string][]geo.Place), len(places)}
+	for name, p := range places {
+		h := Geohash(p, g.precision)
+		g.cells[h] = append(g.cells[h], geo.Place{Name: name, Point: p})
+	}
+	return g
+}
+
+func (g *Grid) Len() int { return g.n }
+
+// Cells returns the number of cells holding places.
+func (g *Grid) Cells() int { return len(g.cells) }
+
+func (g *Grid) Within(p geo.Point, radius float64) []geo.Place {
+	var within []geo.Place
+	if radius >= 0 {
+		// A meter more, for the places on the edges of the box.
+		g.visit(geo.Around(p, radius+1), func(places []geo.Place) {
+			for _, place := range places {
+				if d := geo.Haversine(p, place.Point); d <= radius {
+					place.Distance = d
+					within = append(within, place)
+				}
+			}
+		})
+	}
+	sortPlaces(within)
+	return within
+}
+
+// Nearest looks within a radius of the height of a cell, doubled
+// until it holds k places, or the whole Earth.
+func (g *Grid) Nearest(p geo.Point, k int) []geo.Place {
+	if k <= 0 {
+		return nil
+	}
+	_, latBits := bits(g.precision)
+	radius := math.Pi * geo.EarthRadius / float64(int(1)<<latBits)
+	for {
+		within := g.Within(p, radius)
+		if len(within) >= k || radius >= math.Pi*geo.EarthRadius {
+			return within[:min(k, len(within))]
+		}
+		radius *= 2
+	}
+}
+
+// visit calls f with the places of every cell of the box, or of
+// every cell holding places, when there are fewer.
+func (g *Grid) visit(box geo.Box, f func([]geo.Place)) {
+	minRow, minCol := cell(box.Min, g.precision)
+	maxRow, maxCol := cell(box.Max, g.precision)
+	longBits, _ := bits(g.precision)
+	cols := maxCol - minCol + 1
+	if maxCol < minCol { // across the antimeridian
+		cols += 1 << longBits
+	}
+	if (maxRow-minRow+1)*cols > len(g.cells) {
+		for _, places := range g.cells {
+			f(places)
+		}
+		return
+	}
+	for row := minRow; row <= maxRow; row++ {
+		for i := 0; i < cols; i++ {
+			col := (minCol + i) % (1 << longBits)
+			if places, ok := g.cells[hash(row, col, g.precision)]; ok {
+				f(places)
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package spatial
+
+import (
+	"math"
+
+	"golearning/geo"
+	"golearning/geometry"
+)
+
+// KDTree is a k-d tree of places by their position on the unit
+// sphere, in 3D: the straight distance between two positions, the
+// chord, grows with the distance along the sphere, and does not jump
+// at the antimeridian nor shrink near the poles as longitudes do.
+type KDTree struct {
+	root *node
+	n    int
+}
+
+type node struct {
+	place       geo.Place
+	v           geometry.Vec3[float64]
+	axis        int
+	left, right *node
+}
+
+// position returns p on the unit sphere.
+func position(p geo.Point) geometry.Vec3[float64] {
+	φ, λ := p.Lat*math.Pi/180, p.Long*math.Pi/180
+	return geometry.V3(math.Cos(φ)*math.Cos(λ), math.Cos(φ)*math.Sin(λ), math.Sin(φ))
+}
+
+// coordinate returns the coordinate of v along the axis, 0 for X,
+// 1 for Y and 2 for Z.
+func coordinate(v geometry.Vec3[float64], axis int) float64 {
+	switch axis {
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	}
+	return v.Z
+}
+
+// chord returns the length of the chord of a distance along the
+// Earth, on the unit sphere, slightly lengthened for the rounding
+// errors of the positions.
+func chord(distance float64) float64 {
+	if math.IsInf(distance, 1) || distance >= math.Pi*geo.EarthRadius {
+		return math.Inf(1)
+	}
+	return 2*math.Sin(distance/geo.EarthRadius/2) + 1e-9
+}
+
+// NewKDTree returns a balanced k-d tree of the places.
+func NewKDTree(places map[string]geo.Point) *KDTree {
+	nodes := make([]node, 0, len(places))
+	for name, p := range places {
+		nodes = append(nodes, node{place: geo.Place{Name: name, Point: p}, v: position(p)})
+	}
+	return &KDTree{build(nodes, 0), len(nodes)}
+}
+
+// build builds the tree of nodes, splitting them at their median
+// along the axis of the depth.
+func build(nodes []node, depth int) *node {
+	if len(nodes) == 0 {
+		return nil
+	}
+	axis := depth % 3
+	m := len(nodes) / 2
+	median(nodes, m, axis)
+	n := &nodes[m]
+	n.axis = axis
+	n.left = build(nodes[:m], depth+1)
+	n.right = build(nodes[m+1:], depth+1)
+	return n
+}
+
+// median moves the node of rank m along the axis to nodes[m], the
+// ones before it to its left and the ones after to its right, by
+// quickselect: partitioning around the middle node in the nodes
+// below, equal to and above it, and going on in the part holding m.
+func median(nodes []node, m, axis int) {
+	lo, hi := 0, len(nodes)
+	for hi-lo > 1 {
+		pivot := coordinate(nodes[lo+(hi-lo)/2].v, axis)
+		lt, i, gt := lo, lo, hi
+		for i < gt {
+			switch c := coordinate(nodes[i].v, axis); {
+			case c < pivot:
+				nodes[lt], nodes[i] = nodes[i], nodes[lt]
+				lt, i = lt+1, i+1
+			case c > pivot:
+				gt--
+				nodes[gt], nodes[i] = nodes[i], nodes[gt]
+			default:
+				i++
+			}
+		}
+		switch {
+		case m < lt:
+			hi = lt
+		case m >= gt:
+			lo = gt
+		default:
+			return
+		}
+	}
+}
+
+func (t *KDTree) Len() int { return t.n }
+
+func (t *KDTree) Nearest(p geo.Point, k int) []geo.Place {
+	best := nearest{k: k}
+	if k > 0 {
+		t.root.nearest(p, position(p), &best)
+	}
+	return best.sorted()
+}
+
+func (n *node) nearest(p geo.Point, v geometry.Vec3[float64], best *nearest) {
+	if n == nil {
+		return
+	}
+	place := n.place
+	place.Distance = geo.Haversine(p, place.Point)
+	best.add(place)
+	near, far := n.left, n.right
+	diff := coordinate(v, n.axis) - coordinate(n.v, n.axis)
+	if diff >= 0 {
+		near, far = far, near
+	}
+	near.nearest(p, v, best)
+	// The places across the splitting plane are at least |diff| away.
+	if math.Abs(diff) <= chord(best.worst()) {
+		far.nearest(p, v, best)
+	}
+}
+
+func (t *KDTree) Within(p geo.Point, radius float64) []geo.Place {
+	var within []geo.Place
+	if radius >= 0 {
+		t.root.within(p, position(p), radius, chord(radius), &within)
+	}
+	sortPlaces(within)
+	return within
+}
+
+func (n *node) within(p geo.Point, v geometry.Vec3[float64], radius, chord float64, within *[]geo.Place) {
+	if n == nil {
+		return
+	}
+	if d := geo.Haversine(p, n.place.Point); d <= radius {
+		place := n.place
+		place.Distance = d
+		*within = append(*within, place)
+	}
+	// The left places are at most, the right ones at least, at the
+	// coordinate of n along its axis.
+	diff := coordinate(v, n.axis) - coordinate(n.v, n.axis)
+	if diff <= chord {
+		n.left.within(p, v, radius, chord, within)
+	}
+	if -diff <= chord {
+		n.right.within(p, v, radius, chord, within)
+	}
+}
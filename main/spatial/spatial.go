@@ -0,0 +1,111 @@
+// Package spatial indexes named places, like the map[string]Vertex2 of
+// the maps lesson, to find the k nearest ones of a point, or those
+// within a radius, without scanning them all: a k-d tree of their
+// positions in space, and a grid of their geohashes.
+//
+// Every index answers the same queries as Scan, the linear scan of
+// the map: places ordered by their distance by geo.Haversine, then
+// by name.
+package spatial
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"golearning/geo"
+)
+
+// An Index finds places near a point. Distances are in meters.
+type Index interface {
+	// Nearest returns the k places nearest to p, or all of them if
+	// there are fewer.
+	Nearest(p geo.Point, k int) []geo.Place
+	// Within returns the places within radius of p.
+	Within(p geo.Point, radius float64) []geo.Place
+	// Len returns the number of places.
+	Len() int
+}
+
+// Scan is the Index of a plain map, scanning all its places at
+// every query.
+type Scan map[string]geo.Point
+
+func (s Scan) Nearest(p geo.Point, k int) []geo.Place {
+	var best nearest
+	best.k = k
+	for name, q := range s {
+		best.add(geo.Place{Name: name, Point: q, Distance: geo.Haversine(p, q)})
+	}
+	return best.sorted()
+}
+
+func (s Scan) Within(p geo.Point, radius float64) []geo.Place {
+	var within []geo.Place
+	for name, q := range s {
+		if d := geo.Haversine(p, q); d <= radius {
+			within = append(within, geo.Place{Name: name, Point: q, Distance: d})
+		}
+	}
+	sortPlaces(within)
+	return within
+}
+
+func (s Scan) Len() int { return len(s) }
+
+// less orders places by distance, then by name.
+func less(a, b geo.Place) bool {
+	if a.Distance != b.Distance {
+		return a.Distance < b.Distance
+	}
+	return a.Name < b.Name
+}
+
+func sortPlaces(places []geo.Place) {
+	sort.Slice(places, func(i, j int) bool { return less(places[i], places[j]) })
+}
+
+// nearest keeps the k nearest places added to it, in a max-heap of
+// their distances: the farthest of them is the first to go.
+type nearest struct {
+	k      int
+	places []geo.Place
+}
+
+func (h *nearest) Len() int           { return len(h.places) }
+func (h *nearest) Less(i, j int) bool { return less(h.places[j], h.places[i]) }
+func (h *nearest) Swap(i, j int)      { h.places[i], h.places[j] = h.places[j], h.places[i] }
+func (h *nearest) Push(x any)         { h.places = append(h.places, x.(geo.Place)) }
+func (h *nearest) Pop() any {
+	last := h.places[len(h.places)-1]
+	h.places = h.places[:len(h.places)-1]
+	return last
+}
+
+func (h *nearest) add(p geo.Place) {
+	switch {
+	case h.k <= 0:
+	case len(h.places) < h.k:
+		heap.Push(h, p)
+	case less(p, h.places[0]):
+		h.places[0] = p
+		heap.Fix(h, 0)
+	}
+}
+
+// full reports whether h has k places.
+func (h *nearest) full() bool { return len(h.places) >= h.k }
+
+// worst returns the distance of the farthest place, or +Inf if h is
+// not full: beyond it, places are not worth looking at.
+func (h *nearest) worst() float64 {
+	if !h.full() {
+		return math.Inf(1)
+	}
+	return h.places[0].Distance
+}
+
+func (h *nearest) sorted() []geo.Place {
+	sortPlaces(h.places)
+	return h.places
+}
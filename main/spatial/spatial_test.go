@@ -0,0 +1,195 @@
+package spatial
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	"golearning/geo"
+)
+
+// randomPlaces returns n places spread over the Earth, and packed in
+// a few cities, some at the poles or across the antimeridian.
+func randomPlaces(rnd *rand.Rand, n int) map[string]geo.Point {
+	cities := []geo.Point{
+		{Lat: 40.68433, Long: -74.39967},
+		{Lat: 37.42202, Long: -122.08408},
+		{Lat: 89.99, Long: 0},
+		{Lat: -89.9, Long: 45},
+		{Lat: -16.5, Long: 179.99},
+	}
+	places := make(map[string]geo.Point, n)
+	for i := 0; i < n; i++ {
+		var p geo.Point
+		if i%2 == 0 {
+			c := cities[rnd.Intn(len(cities))]
+			p = geo.Destination(c, rnd.Float64()*360, rnd.ExpFloat64()*20e3)
+		} else {
+			p = geo.Point{Lat: math.Asin(2*rnd.Float64()-1) * 180 / math.Pi, Long: rnd.Float64()*360 - 180}
+		}
+		places[fmt.Sprint("place", i)] = p
+	}
+	return places
+}
+
+func names(places []geo.Place) []string {
+	names := make([]string, len(places))
+	for i, p := range places {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func TestIndexes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	places := randomPlaces(rnd, 2000)
+	scan := Scan(places)
+	indexes := map[string]Index{
+		"KDTree":  NewKDTree(places),
+		"Grid":    NewGrid(places, 0),
+		"Grid(2)": NewGrid(places, 2),
+		"Grid(8)": NewGrid(places, 8),
+	}
+	queries := []geo.Point{
+		{Lat: 40.7, Long: -74.0},
+		{Lat: 90, Long: 0},
+		{Lat: -90, Long: 0},
+		{Lat: -16.5, Long: -179.99},
+		{Lat: 0, Long: 180},
+	}
+	for i := 0; i < 20; i++ {
+		queries = append(queries, geo.Point{Lat: rnd.Float64()*180 - 90, Long: rnd.Float64()*360 - 180})
+	}
+	for name, index := range indexes {
+		if index.Len() != len(places) {
+			t.Errorf("%s.Len() = %d, want %d", name, index.Len(), len(places))
+		}
+		for _, p := range queries {
+			for _, k := range []int{0, 1, 5, 40} {
+				got, want := index.Nearest(p, k), scan.Nearest(p, k)
+				if !reflect.DeepEqual(names(got), names(want)) {
+					t.Errorf("%s.Nearest(%v, %d) = %v, want %v", name, p, k, names(got), names(want))
+				}
+			}
+			for _, radius := range []float64{0, 1e3, 30e3, 500e3, 3000e3, 30000e3} {
+				got, want := index.Within(p, radius), scan.Within(p, radius)
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%s.Within(%v, %v) = %d places, want %d", name, p, radius, len(got), len(want))
+				}
+			}
+		}
+	}
+}
+
+func TestNearestAll(t *testing.T) {
+	places := map[string]geo.Point{
+		"Bell Labs": {Lat: 40.68433, Long: -74.39967},
+		"Google":    {Lat: 37.42202, Long: -122.08408},
+		"Sydney":    {Lat: -33.8688, Long: 151.2093},
+	}
+	london := geo.Point{Lat: 51.5, Long: -0.12}
+	want := []string{"Bell Labs", "Google", "Sydney"}
+	for name, index := range map[string]Index{"Scan": Scan(places), "KDTree": NewKDTree(places), "Grid": NewGrid(places, 0)} {
+		got := index.Nearest(london, 10)
+		if !reflect.DeepEqual(names(got), want) {
+			t.Errorf("%s.Nearest(London, 10) = %v, want %v", name, names(got), want)
+		}
+		if got[0].Point != places["Bell Labs"] || got[0].Distance != geo.Haversine(london, places["Bell Labs"]) {
+			t.Errorf("%s.Nearest(London, 10)[0] = %+v", name, got[0])
+		}
+	}
+	if got := NewKDTree(nil).Nearest(london, 3); len(got) != 0 {
+		t.Errorf("empty KDTree.Nearest = %v", got)
+	}
+	if got := NewGrid(nil, 0).Nearest(london, 3); len(got) != 0 {
+		t.Errorf("empty Grid.Nearest = %v", got)
+	}
+}
+
+func TestSamePoint(t *testing.T) {
+	// Places at the same distance come by name.
+	places := make(map[string]geo.Point)
+	for i := 0; i < 1000; i++ {
+		places[fmt.Sprint("place", i)] = geo.Point{Lat: 90, Long: float64(i%360 - 180)}
+	}
+	p := geo.Point{Lat: 80, Long: 0}
+	want := names(Scan(places).Nearest(p, 5))
+	for name, index := range map[string]Index{"KDTree": NewKDTree(places), "Grid": NewGrid(places, 0)} {
+		if got := names(index.Nearest(p, 5)); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s.Nearest = %v, want %v", name, got, want)
+		}
+		if got := index.Within(p, 1200e3); len(got) != len(places) {
+			t.Errorf("%s.Within = %d places, want %d", name, len(got), len(places))
+		}
+	}
+}
+
+func TestGeohash(t *testing.T) {
+	for _, test := range []struct {
+		p    geo.Point
+		want string
+	}{
+		{geo.Point{Lat: 57.64911, Long: 10.40744}, "u4pruydqqvj"},
+		{geo.Point{Lat: 42.6, Long: -5.6}, "ezs42e44yx9"},
+		{geo.Point{Lat: -90, Long: -180}, "00000000000"},
+		{geo.Point{Lat: 90, Long: 180}, "zzzzzzzzzzz"},
+		{geo.Point{Lat: 0, Long: 0}, "s0000000000"},
+	} {
+		if got := Geohash(test.p, 11); got != test.want {
+			t.Errorf("Geohash(%v, 11) = %s, want %s", test.p, got, test.want)
+		}
+		box, err := DecodeGeohash(test.want)
+		if err != nil || !box.Contains(test.p) {
+			t.Errorf("DecodeGeohash(%s) = %v, %v, does not contain %v", test.want, box, err, test.p)
+		}
+	}
+	if got := Geohash(geo.Point{Lat: 57.64911, Long: 10.40744}, 1); got != "u" {
+		t.Errorf("Geohash(1) = %s, want u", got)
+	}
+	box, _ := DecodeGeohash("ezs42")
+	if math.Abs(box.Min.Lat-42.583) > 1e-3 || math.Abs(box.Max.Long+5.581) > 1e-3 {
+		t.Errorf("DecodeGeohash(ezs42) = %v", box)
+	}
+	for _, h := range []string{"", "ab", "0123456789bcd"} {
+		if _, err := DecodeGeohash(h); err == nil {
+			t.Errorf("DecodeGeohash(%q) = nil error", h)
+		}
+	}
+}
+
+// benchPlaces are a few hundred thousand places, made once and only
+// for the benchmarks.
+var benchPlaces = sync.OnceValue(func() map[string]geo.Point {
+	return randomPlaces(rand.New(rand.NewSource(1)), 300000)
+})
+
+func benchmark(b *testing.B, index Index, query func(Index, geo.Point)) {
+	rnd := rand.New(rand.NewSource(2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		query(index, geo.Point{Lat: rnd.Float64()*180 - 90, Long: rnd.Float64()*360 - 180})
+	}
+}
+
+func BenchmarkNearest(b *testing.B) {
+	nearest := func(index Index, p geo.Point) { index.Nearest(p, 10) }
+	b.Run("Scan", func(b *testing.B) { benchmark(b, Scan(benchPlaces()), nearest) })
+	b.Run("KDTree", func(b *testing.B) { benchmark(b, NewKDTree(benchPlaces()), nearest) })
+	b.Run("Grid", func(b *testing.B) { benchmark(b, NewGrid(benchPlaces(), 3), nearest) })
+}
+
+func BenchmarkWithin(b *testing.B) {
+	within := func(index Index, p geo.Point) { index.Within(p, 100e3) }
+	b.Run("Scan", func(b *testing.B) { benchmark(b, Scan(benchPlaces()), within) })
+	b.Run("KDTree", func(b *testing.B) { benchmark(b, NewKDTree(benchPlaces()), within) })
+	b.Run("Grid", func(b *testing.B) { benchmark(b, NewGrid(benchPlaces(), 0), within) })
+}
+
+func BenchmarkNewKDTree(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewKDTree(benchPlaces())
+	}
+}
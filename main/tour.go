@@ -5,9 +5,9 @@ package main
 
 import (
 	"fmt"
+	"golearning/numeric"
 	"math"
 	"math/cmplx"
-	"math/rand"
 	"runtime"
 	"time"
 )
@@ -28,111 +28,174 @@ const (
 	Small = Big >> 99
 )
 
-func main() {
-	fmt.Println("My favorite number is", rand.Intn(10))
-	fmt.Println("My favorite number is", rand.Intn(10))
+var basics = lesson{
+	name:  "basics",
+	title: "Packages, variables and functions; for, if, else, switch and defer",
+	steps: []step{
+		{"packages", packages},
+		{"functions", functions},
+		{"variables", variables},
+		{"constants", constants},
+		{"for", forLoops},
+		{"if", ifElse},
+		{"sqrtFinder", loopsAndFunctions},
+		{"solvers", rootFinders},
+		{"switch", switchCase},
+		{"defer", deferStatement},
+	},
+}
+
+func packages(ctx *Context) {
+	ctx.Println("My favorite number is", ctx.rand.Intn(10))
+	ctx.Println("My favorite number is", ctx.rand.Intn(10))
 
 	// Using format
-	fmt.Printf("Now you have %g problems.\n", math.Sqrt(7))
+	ctx.Printf("Now you have %g problems.\n", math.Sqrt(7))
 
 	// In Go, a name is exported if it begins with a capital letter
-	fmt.Println("math.Pi =", math.Pi)
+	ctx.Println("math.Pi =", math.Pi)
+}
 
+func functions(ctx *Context) {
 	// A function can take zero or more arguments.
-	fmt.Println("add method:", add(42, 13))
+	ctx.Println("add method:", add(42, 13))
 
 	// When two or more consecutive named function parameters
 	// share a type, you can omit the type from all but the last.
-	fmt.Println("multiply method:", multiply(300, 2))
+	ctx.Println("multiply method:", multiply(300, 2))
 
 	// A function can return any number of results.
 	a, b := swap("hello", "world")
-	fmt.Println("Swap method returns:", a, b)
+	ctx.Println("Swap method returns:", a, b)
 
 	// Go's return values may be named. If so, they are treated
 	// as variables defined at the top of the function.
-	fmt.Print("Split method for 34: ")
-	fmt.Println(split(34))
+	ctx.Print("Split method for 34: ")
+	ctx.Println(split(34))
+}
 
+func variables(ctx *Context) {
 	// The var statement declares a list of variables;
 	// as in function argument lists, the type is last
 	var c, python, java bool
 	var i int
-	fmt.Println("Some variables:", i, c, python, java)
+	ctx.Println("Some variables:", i, c, python, java)
 
 	// A var declaration can include initializers, one per variable.
 	// If an initializer is present, the type can be omitted
 	var c2, python2, java2 = true, false, "no!"
 	var i2, j2 int = 1, 2
-	fmt.Println("Some variables (2):", i2, j2, c2, python2, java2)
+	ctx.Println("Some variables (2):", i2, j2, c2, python2, java2)
 
 	// Inside a function, the := short assignment statement can be
 	// used in place of a var declaration with implicit type.
 	k := 3
-	fmt.Println("Some variables (3):", k)
+	ctx.Println("Some variables (3):", k)
 
 	// Variables can be declared in a block (see var block)
-	fmt.Printf("Type: %T Value: %v\n", ToBe, ToBe)
-	fmt.Printf("Type: %T Value: %v\n", MaxInt, MaxInt)
-	fmt.Printf("Type: %T Value: %v\n", z, z)
+	ctx.Printf("Type: %T Value: %v\n", ToBe, ToBe)
+	ctx.Printf("Type: %T Value: %v\n", MaxInt, MaxInt)
+	ctx.Printf("Type: %T Value: %v\n", z, z)
 
 	// Variables declared without an explicit initial value are given their zero value
 	var i3 int
 	var f float64
 	var b2 bool
 	var s string
-	fmt.Printf("Default variables type value %v %v %v %q\n", i3, f, b2, s)
+	ctx.Printf("Default variables type value %v %v %v %q\n", i3, f, b2, s)
 
 	// T(v) convert the value v to the T type
 	i4 := 42
 	f2 := float64(i)
 	u2 := uint(f)
-	fmt.Println("Some variables (4):", i4, f2, u2)
+	ctx.Println("Some variables (4):", i4, f2, u2)
 
 	// the variable's type is inferred from the value on the right hand side.
 	i5 := 42          // int
 	f3 := 3.142       // float64
 	g := 0.867 + 0.5i // complex128
-	fmt.Println("Some variables (5):", i5, f3, g)
+	ctx.Println("Some variables (5):", i5, f3, g)
+}
 
+func constants(ctx *Context) {
 	// Constants are declared like variables, but with the const keyword.
 	const World = "世界"
-	fmt.Println("Hello", World)
+	ctx.Println("Hello", World)
 
 	// Numeric constants are high-precision values.
 	// An untyped constant takes the type needed by its context.
-	fmt.Println("needInt(Small):", needInt(Small))
-	fmt.Println("needFloat(Small):", needFloat(Small))
-	fmt.Println("needFloat(Big):", needFloat(Big))
+	ctx.Println("needInt(Small):", needInt(Small))
+	ctx.Println("needFloat(Small):", needFloat(Small))
+	ctx.Println("needFloat(Big):", needFloat(Big))
+}
 
+func forLoops(ctx *Context) {
 	// Only looping construct, the for loop (see git push -u -f origin master)
-	fmt.Println("sum10Times(12):", sum10Times(12))
+	ctx.Println("sum10Times(12):", sum10Times(12))
 
 	//  In the loop init and post statements are optional.
-	fmt.Println("sumWithLoop(400):", sumWithLoop(400))
+	ctx.Println("sumWithLoop(400):", sumWithLoop(400))
+}
 
+func ifElse(ctx *Context) {
 	// Using if (see method)
-	fmt.Println("sqrt(2):", sqrt(2), "sqrt(-4):", sqrt(-4))
+	ctx.Println("sqrt(2):", sqrt(2), "sqrt(-4):", sqrt(-4))
 
 	// Using if with condition (see method)
 	// Using else (see method)
-	fmt.Println("pow(3, 2, 10):", pow(3, 2, 10),
-		"pow(3, 3, 20):", pow(3, 3, 20))
+	ctx.Println("pow(3, 2, 10):", pow(ctx, 3, 2, 10),
+		"pow(3, 3, 20):", pow(ctx, 3, 3, 20))
+}
 
-	fmt.Println("sqrtFinder(81):", sqrtFinder(81))
+// Exercise: Loops and Functions
+func loopsAndFunctions(ctx *Context) {
+	ctx.Println("sqrtFinder(81):", sqrtFinder(ctx, 81))
+}
 
+// sqrtFinder generalized in the "golearning/numeric" package:
+// the method changes how many iterations the root takes.
+func rootFinders(ctx *Context) {
+	f := func(z float64) float64 { return z*z - 81 }
+	newton, _ := newtonSqrt(81, nil)
+	secant, _ := numeric.Secant(f, 1, 2, nil)
+	bisection, _ := numeric.Bisection(f, 0, 81, nil)
+	brent, _ := numeric.Brent(f, 0, 81, nil)
+	for _, s := range []struct {
+		name string
+		r    numeric.Result
+	}{
+		{"Newton", newton},
+		{"Secant", secant},
+		{"Bisection", bisection},
+		{"Brent", brent},
+	} {
+		ctx.Printf("%-9s root of z*z - 81: %v in %d iterations\n", s.name, s.r.Root, s.r.Iterations())
+	}
+	ctx.Println("Newton trace:")
+	for i, step := range newton.Trace {
+		ctx.Printf("i= %d |--> z= %v f(z)= %.3g\n", i, step.X, step.FX)
+	}
+	cbrt, _ := numeric.NthRoot(-27, 3)
+	ctx.Println("numeric.NthRoot(-27, 3):", cbrt)
+	_, err := numeric.NthRoot(-81, 2)
+	ctx.Println("numeric.NthRoot(-81, 2):", err)
+}
+
+func switchCase(ctx *Context) {
 	// Switch case (see method)
-	fmt.Println("getOS():", getOS())
+	ctx.Println("getOS():", getOS())
 
 	// Switch case with evaluate case
-	fmt.Println("When's Saturday?", FindSaturday())
-	fmt.Println(greetings())
+	ctx.Println("When's Saturday?", FindSaturday(ctx.clock))
+	ctx.Println(greetings(ctx.clock))
+}
 
+func deferStatement(ctx *Context) {
 	// A defer statement defers the execution of a function
 	// until the surrounding function returns.
-	defer fmt.Println("LAST MESSAGE")
+	defer ctx.Println("LAST MESSAGE")
 
-	countingUsingDefer()
+	countingUsingDefer(ctx)
 }
 
 func add(x int, y int) int {
@@ -181,7 +244,7 @@ func sqrt(x float64) string {
 	return fmt.Sprint(math.Sqrt(x))
 }
 
-func pow(x, n, lim float64) float64 {
+func pow(ctx *Context, x, n, lim float64) float64 {
 	//  Like for, the if statement can start with a short
 	// statement to execute before the condition.
 	// ---
@@ -192,25 +255,31 @@ func pow(x, n, lim float64) float64 {
 	} else {
 		// Variables declared inside an if short statement
 		// are also available inside any of the else blocks.
-		fmt.Println("%g >= %g\n", v, lim)
+		ctx.Printf("%g >= %g\n", v, lim)
 	}
 	// can't use v here, though
 	return lim
 }
 
 // Loop and if
-func sqrtFinder(x float64) float64 {
-	z := float64(1)
-	previous := z
-	for i := 0; i < 10; i++ {
-		z -= (z*z - x) / (2 * z)
-		fmt.Println("i=", i, "|--> z=", z)
-		if math.Abs(z-previous) < 0.001 {
-			return z
-		}
-		previous = z
+// The loop is numeric.Newton on z*z - x, from z = 1: it runs until
+// z changes by less than the tolerance of the package.
+func sqrtFinder(ctx *Context, x float64) float64 {
+	r, _ := newtonSqrt(x, nil)
+	for i, step := range r.Trace {
+		ctx.Println("i=", i, "|--> z=", step.X)
 	}
-	return z
+	return r.Root
+}
+
+// newtonSqrt runs the Newton iteration of sqrtFinder,
+// z -= (z*z - x) / (2*z), from z = 1. z*z - x is computed with
+// a single rounding, so that the last iterations see how far
+// from x the square of z really is.
+func newtonSqrt(x float64, opts *numeric.Options) (numeric.Result, error) {
+	f := func(z float64) float64 { return math.FMA(z, z, -x) }
+	df := func(z float64) float64 { return 2 * z }
+	return numeric.Newton(f, df, 1, opts)
 }
 
 // Switch case
@@ -227,8 +296,8 @@ func getOS() string {
 	}
 }
 
-func FindSaturday() string {
-	today := time.Now().Weekday()
+func FindSaturday(clock Clock) string {
+	today := clock.Now().Weekday()
 	switch time.Saturday {
 	case today + 0:
 		return "Today."
@@ -241,8 +310,8 @@ func FindSaturday() string {
 	}
 }
 
-func greetings() string {
-	t := time.Now()
+func greetings(clock Clock) string {
+	t := clock.Now()
 	// Switch without a condition is the same as switch true.
 	// This construct can be a clean way to write long
 	// if-then-else chains.
@@ -256,11 +325,11 @@ func greetings() string {
 	}
 }
 
-func countingUsingDefer() {
-	fmt.Println("countingUsingDefer start")
+func countingUsingDefer(ctx *Context) {
+	ctx.Println("countingUsingDefer start")
 	for i := 0; i < 10; i++ {
 		// bad practice, can cause issues
-		defer fmt.Println("countingUsingDefer:", i)
+		defer ctx.Println("countingUsingDefer:", i)
 	}
-	fmt.Println("countingUsingDefer done")
+	ctx.Println("countingUsingDefer done")
 }
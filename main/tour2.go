@@ -6,49 +6,64 @@ package main
 import (
 	"fmt"
 	"math"
+	"strings"
+
+	"golearning/functional"
+	"golearning/geo"
+	"golearning/sliceviz"
+	"golearning/spatial"
 )
 
-func main() {
-	// Go has pointers. A pointer holds the memory
-	// address of a value.
-	// The type *T is a pointer to a T value.
-	// Its zero value is nil.
-	pointers()
-
-	// Struct fields are accessed using a dot
-	vertex()
-	vertex2()
-	vertex3()
-
-	// Arrays & Slices
-	arrays()
-	slices()
-	slices2()
-	slices3()
-	printSlice([]int{2, 3, 5, 7, 11, 13})
-	emptySlice()
-	slicesRange()
-
-	// Maps
-	maps()
-	mapLiterals()
-	mapMutating()
-
-	// Functions as values
-	useFunctionAsValue()
-	functionClosures()
-	fibonacciClosure()
+var collections = lesson{
+	name:  "collections",
+	title: "Pointers, Structs, Slices and Maps; Functions as values",
+	steps: []step{
+		// Go has pointers. A pointer holds the memory
+		// address of a value.
+		// The type *T is a pointer to a T value.
+		// Its zero value is nil.
+		{"pointers", pointers},
+
+		// Struct fields are accessed using a dot
+		{"vertex", vertex},
+		{"vertex2", vertex2},
+		{"vertex3", vertex3},
+
+		// Arrays & Slices
+		{"arrays", arrays},
+		{"slices", slices},
+		{"slices2", slices2},
+		{"slices3", slices3},
+		{"printSlice", func(ctx *Context) { printSlice(ctx, []int{2, 3, 5, 7, 11, 13}) }},
+		{"sliceInternals", sliceInternals},
+		{"appendGrowth", appendGrowth},
+		{"emptySlice", emptySlice},
+		{"slicesRange", slicesRange},
+
+		// Maps
+		{"maps", maps},
+		{"mapLiterals", mapLiterals},
+		{"mapMutating", mapMutating},
+		{"geo", geography},
+		{"spatialIndex", spatialIndex},
+
+		// Functions as values
+		{"useFunctionAsValue", useFunctionAsValue},
+		{"functionClosures", functionClosures},
+		{"fibonacciClosure", fibonacciClosure},
+		{"higherOrder", higherOrder},
+	},
 }
 
-func pointers() {
+func pointers(ctx *Context) {
 	i, j := 42, 2701
 	p := &i                // point to i
-	fmt.Println("*p:", *p) // read i through the pointer
+	ctx.Println("*p:", *p) // read i through the pointer
 	*p = 21                // set i through the pointer
-	fmt.Println("i:", i)   // see the new value of i
+	ctx.Println("i:", i)   // see the new value of i
 	p = &j                 // point to j
 	*p = *p / 37           // divide j through the pointer
-	fmt.Println("j:", j)   // see the new value of j
+	ctx.Println("j:", j)   // see the new value of j
 }
 
 // A struct is a collection of fields.
@@ -57,14 +72,14 @@ type Vertex struct {
 	Y int
 }
 
-func vertex() {
-	fmt.Println("Vertex{1, 2}:", Vertex{1, 2})
+func vertex(ctx *Context) {
+	ctx.Println("Vertex{1, 2}:", Vertex{1, 2})
 	v := Vertex{1, 2}
 	v.X = 4
-	fmt.Println("Vertex x:", v.X)
+	ctx.Println("Vertex x:", v.X)
 }
 
-func vertex2() {
+func vertex2(ctx *Context) {
 	/*
 		Struct fields can be accessed through a struct pointer.
 		To access the field X of a struct when we have the
@@ -76,18 +91,18 @@ func vertex2() {
 	v := Vertex{1, 2}
 	p := &v
 	p.X = 1e9
-	fmt.Println("Vertex{X, Y}:", v)
+	ctx.Println("Vertex{X, Y}:", v)
 }
 
-func vertex3() {
+func vertex3(ctx *Context) {
 	v1 := Vertex{1, 2} // has type Vertex
 	v2 := Vertex{X: 1} // Y:0 is implicit
 	v3 := Vertex{}     // X:0 and Y:0
 	p := &Vertex{1, 2} // has type *Vertex
-	fmt.Println("v1, p, v2, v3:", v1, p, v2, v3)
+	ctx.Println("v1, p, v2, v3:", v1, p, v2, v3)
 }
 
-func arrays() {
+func arrays(ctx *Context) {
 	/*
 		An array's length is part of its type, so arrays
 		cannot be resized. This seems limiting, but don't worry;
@@ -96,19 +111,19 @@ func arrays() {
 	var a [2]string
 	a[0] = "Hello"
 	a[1] = "World"
-	fmt.Println(a[0], a[1])
-	fmt.Println(a)
+	ctx.Println(a[0], a[1])
+	ctx.Println(a)
 	primes := [6]int{2, 3, 5, 7, 11, 13}
-	fmt.Println(primes)
+	ctx.Println(primes)
 }
 
-func slices() {
+func slices(ctx *Context) {
 	primes := [6]int{2, 3, 5, 7, 11, 13} // array
 	var s []int = primes[1:4]            // slice
-	fmt.Println("primes[1:4]:", s)
+	ctx.Println("primes[1:4]:", s)
 }
 
-func slices2() {
+func slices2(ctx *Context) {
 	/*
 		A slice does not store any data, it just describes a section
 		of an underlying array.
@@ -123,69 +138,114 @@ func slices2() {
 		"George",
 		"Ringo",
 	}
-	fmt.Println("names:", names)
+	ctx.Println("names:", names)
 
 	a := names[0:2]
 	b := names[1:3]
-	fmt.Println("names[0:2]:", a, "names[1:3]:", b)
+	ctx.Println("names[0:2]:", a, "names[1:3]:", b)
 
 	b[0] = "XXX"
-	fmt.Println("b[0] = \"XXX\"")
-	fmt.Println("names[0:2]:", a, "names[1:3]:", b)
-	fmt.Println("names:", names)
+	ctx.Println("b[0] = \"XXX\"")
+	ctx.Println("names[0:2]:", a, "names[1:3]:", b)
+	ctx.Println("names:", names)
 }
 
-func slices3() {
+func slices3(ctx *Context) {
 	s := []int{2, 3, 5, 7, 11, 13}
-	fmt.Println("[]int{2, 3, 5, 7, 11, 13}:", s)
+	ctx.Println("[]int{2, 3, 5, 7, 11, 13}:", s)
 	// The make function allocates a zeroed array
 	// and returns a slice that refers to that array.
 	// append is a built-in function to append elements
 	// to a slice.
 	s = append(make([]int, 0), 2, 3, 5, 7, 11, 13)
-	fmt.Println("s", s)
+	ctx.Println("s", s)
 	s = s[1:4]
-	fmt.Println("s[1:4]:", s)
+	ctx.Println("s[1:4]:", s)
 	s = []int{2, 3, 5, 7, 11, 13}
 	s = s[:2]
-	fmt.Println("s[2:]:", s)
+	ctx.Println("s[2:]:", s)
 	s = []int{2, 3, 5, 7, 11, 13}
 	s = s[1:]
-	fmt.Println("s[1:]:", s)
+	ctx.Println("s[1:]:", s)
+}
+
+func printSlice(ctx *Context, s []int) {
+	ctx.Printf("len=%d cap=%d %v\n", len(s), cap(s), s)
 }
 
-func printSlice(s []int) {
-	fmt.Printf("len=%d cap=%d %v\n", len(s), cap(s), s)
+// printSlice, drawing the arrays behind the slices of the slices,
+// slices2 and slices3 steps: "golearning/sliceviz"
+func sliceInternals(ctx *Context) {
+	ctx.Println("slices:")
+	primes := [6]int{2, 3, 5, 7, 11, 13}
+	sliceviz.Fprint(ctx, sliceviz.Named("primes", primes[:]), sliceviz.Named("primes[1:4]", primes[1:4]))
+
+	// b[0] = "XXX" changes a and names: they share an array.
+	ctx.Println("slices2:")
+	names := [4]string{"John", "Paul", "George", "Ringo"}
+	a, b := names[0:2], names[1:3]
+	b[0] = "XXX"
+	sliceviz.Fprint(ctx, sliceviz.Named("names", names[:]), sliceviz.Named("a", a), sliceviz.Named("b", b))
+
+	// Slicing s again keeps its array, and s[1:] can never see the
+	// first element again: its window starts after it.
+	ctx.Println("slices3:")
+	s := []int{2, 3, 5, 7, 11, 13}
+	var t []int
+	sliceviz.Fprint(ctx, sliceviz.Named("s", s),
+		sliceviz.Named("s[1:4]", s[1:4]), sliceviz.Named("s[:2]", s[:2]), sliceviz.Named("s[1:]", s[1:]),
+		sliceviz.Named("s[1:4][:4]", s[1:4][:4]), sliceviz.Named("var t []int", t))
 }
 
-func emptySlice() {
+// append reuses the array of a slice while it has room, and else
+// copies the slice to a new, larger array: the slices of the old one
+// do not see the new elements.
+func appendGrowth(ctx *Context) {
+	s := []int{2, 3, 5}
+	old := s
+	s, trace := sliceviz.Append(s, 7, 11, 13, 17, 19, 23)
+	sliceviz.FprintGrowth(ctx, trace)
+	sliceviz.Fprint(ctx, sliceviz.Named("old", old), sliceviz.Named("s", s))
+
+	// Appending to a slice with room writes to the array it shares.
+	primes := []int{2, 3, 5, 7, 11, 13}
+	a := primes[:2]
+	a, trace = sliceviz.Append(a, 99)
+	ctx.Println("a = append(primes[:2], 99):", trace[0])
+	sliceviz.Fprint(ctx, sliceviz.Named("primes", primes), sliceviz.Named("a", a))
+
+	ctx.Println("the arrays of 2000 appends:")
+	sliceviz.FprintGrowth(ctx, sliceviz.Grow([]int(nil), 2000))
+}
+
+func emptySlice(ctx *Context) {
 	var s []int
-	fmt.Println(s, len(s), cap(s))
+	ctx.Println(s, len(s), cap(s))
 	if s == nil {
-		fmt.Println("nil!")
+		ctx.Println("nil!")
 	}
 }
 
-func slicesRange() {
+func slicesRange(ctx *Context) {
 	/*
 		The range form of the for loop iterates over a slice or map.
 		When ranging over a slice, two values are returned for each
 		iteration. The first is the index, and the second is a copy of
 		the element at that index.
 	*/
-	fmt.Println("slicesRange():")
+	ctx.Println("slicesRange():")
 	var pow = []int{1, 2, 4, 8, 16, 32, 64, 128}
-	fmt.Println("index and value:")
+	ctx.Println("index and value:")
 	for i, v := range pow {
-		fmt.Printf("2**%d = %d\n", i, v)
+		ctx.Printf("2**%d = %d\n", i, v)
 	}
 	pow = make([]int, 10)
 	for i := range pow {
 		pow[i] = 1 << uint(i) // == 2**i
 	}
-	fmt.Println("value only:")
+	ctx.Println("value only:")
 	for _, value := range pow {
-		fmt.Printf("%d\n", value)
+		ctx.Printf("%d\n", value)
 	}
 }
 
@@ -193,16 +253,16 @@ type Vertex2 struct {
 	Lat, Long float64
 }
 
-func maps() {
+func maps(ctx *Context) {
 	var m map[string]Vertex2
 	m = make(map[string]Vertex2)
 	m["Bell Labs"] = Vertex2{
 		40.68433, -74.39967,
 	}
-	fmt.Println(m["Bell Labs"])
+	ctx.Println(m["Bell Labs"])
 }
 
-func mapLiterals() {
+func mapLiterals(ctx *Context) {
 	/*
 		Map literals are like struct literals,
 		but the keys are required.
@@ -211,37 +271,104 @@ func mapLiterals() {
 		"Bell Labs": {40.68433, -74.39967},
 		"Google":    {37.42202, -122.08408},
 	}
-	fmt.Println(m)
+	ctx.Println(m)
 }
 
-func mapMutating() {
+// The places of the map lessons, on Earth: "golearning/geo"
+func geography(ctx *Context) {
+	var m = map[string]Vertex2{
+		"Bell Labs": {40.68433, -74.39967},
+		"Google":    {37.42202, -122.08408},
+		"Go Tour":   {37.78, -122.42}, // San Francisco
+	}
+	// A Vertex2 has the fields of a geo.Point, so it converts to one.
+	points := geoPoints(m)
+	bell, google := geo.Point(m["Bell Labs"]), geo.Point(m["Google"])
+	ctx.Printf("Haversine(Bell Labs, Google): %.1f km\n", geo.Haversine(bell, google)/1000)
+	d, _ := geo.Vincenty(bell, google)
+	ctx.Printf("Vincenty(Bell Labs, Google): %.1f km\n", d/1000)
+	ctx.Printf("Bearing(Bell Labs, Google): %.1f°\n", geo.Bearing(bell, google))
+	p := geo.Destination(bell, 90, 100e3)
+	ctx.Printf("100 km east of Bell Labs: %.4f\n", p)
+	ctx.Printf("within 10 km of Google: %.4f\n", geo.Around(google, 10e3))
+
+	home := geo.Point(Vertex2{37.39, -122.08}) // Mountain View
+	name, d, _ := geo.Nearest(points, home)
+	ctx.Printf("nearest to %v: %s, %.1f km\n", home, name, d/1000)
+	ctx.Println("invalid:", geo.Point(Vertex2{91, 0}).Validate())
+
+	var b strings.Builder
+	geo.WriteGeoJSON(&b, points)
+	places, _ := geo.ReadGeoJSON(strings.NewReader(b.String()))
+	ctx.Println("GeoJSON round trip:", places)
+}
+
+// Finding the places nearest to a point in a map means looking at
+// all of them; "golearning/spatial" indexes them once, to look only
+// at the few that may be near.
+func spatialIndex(ctx *Context) {
+	m := make(map[string]Vertex2)
+	for lat := -90; lat <= 90; lat++ {
+		for long := -180; long < 180; long++ {
+			m[fmt.Sprintf("%d,%d", lat, long)] = Vertex2{float64(lat), float64(long)}
+		}
+	}
+	points := geoPoints(m)
+	bell := geo.Point(Vertex2{40.68433, -74.39967})
+	ctx.Println("places:", len(points))
+
+	tree := spatial.NewKDTree(points)
+	for _, place := range tree.Nearest(bell, 3) {
+		ctx.Printf("near Bell Labs: %s, %.1f km\n", place.Name, place.Distance/1000)
+	}
+	ctx.Println("within 150 km of Bell Labs:", len(tree.Within(bell, 150e3)))
+	ctx.Println("within 150 km of the North Pole:", len(tree.Within(geo.Point{Lat: 90}, 150e3)))
+
+	// A geohash names the cell of a point in a grid, finer at each
+	// character: nearby points share a prefix.
+	ctx.Println("Geohash(Bell Labs):", spatial.Geohash(bell, 9))
+	grid := spatial.NewGrid(points, 3)
+	ctx.Println("grid cells:", grid.Cells())
+	ctx.Println("grid nearest:", grid.Nearest(bell, 1)[0].Name)
+}
+
+// geoPoints converts the places of m to the points of "golearning/geo".
+func geoPoints(m map[string]Vertex2) map[string]geo.Point {
+	points := make(map[string]geo.Point, len(m))
+	for name, v := range m {
+		points[name] = geo.Point(v)
+	}
+	return points
+}
+
+func mapMutating(ctx *Context) {
 	m := make(map[string]int)
 
 	m["Answer"] = 42
-	fmt.Println("The value:", m["Answer"])
+	ctx.Println("The value:", m["Answer"])
 
 	m["Answer"] = 48
-	fmt.Println("The value:", m["Answer"])
+	ctx.Println("The value:", m["Answer"])
 
 	delete(m, "Answer")
-	fmt.Println("The value:", m["Answer"])
+	ctx.Println("The value:", m["Answer"])
 
 	v, ok := m["Answer"]
-	fmt.Println("The value:", v, "Present?", ok)
+	ctx.Println("The value:", v, "Present?", ok)
 }
 
 func compute(fn func(float64, float64) float64) float64 {
 	return fn(3, 4)
 }
 
-func useFunctionAsValue() {
-	fmt.Println("useFunctionAsValue():")
+func useFunctionAsValue(ctx *Context) {
+	ctx.Println("useFunctionAsValue():")
 	hypot := func(x, y float64) float64 {
 		return math.Sqrt(x*x + y*y)
 	}
-	fmt.Println(hypot(5, 12))
-	fmt.Println(compute(hypot))
-	fmt.Println(compute(math.Pow))
+	ctx.Println(hypot(5, 12))
+	ctx.Println(compute(hypot))
+	ctx.Println(compute(math.Pow))
 }
 
 func adder() func(int) int {
@@ -252,8 +379,8 @@ func adder() func(int) int {
 	}
 }
 
-func functionClosures() {
-	fmt.Println("functionClosures():")
+func functionClosures(ctx *Context) {
+	ctx.Println("functionClosures():")
 	/*
 		Go functions may be closures. A closure is a function
 		value that references variables from outside its body.
@@ -263,7 +390,7 @@ func functionClosures() {
 	*/
 	pos, neg := adder(), adder()
 	for i := 0; i < 10; i++ {
-		fmt.Println(
+		ctx.Println(
 			pos(i),
 			neg(-2*i),
 		)
@@ -282,10 +409,56 @@ func fibonacci() func() int {
 	}
 }
 
-func fibonacciClosure() {
-	fmt.Println("fibonacciClosure():")
+func fibonacciClosure(ctx *Context) {
+	ctx.Println("fibonacciClosure():")
 	f := fibonacci()
 	for i := 0; i < 10; i++ {
-		fmt.Println(f())
+		ctx.Println(f())
+	}
+}
+
+// Functions taking and making functions, for any types:
+// "golearning/functional"
+func higherOrder(ctx *Context) {
+	ctx.Println("higherOrder():")
+	hypot := func(x, y float64) float64 {
+		return math.Sqrt(x*x + y*y)
 	}
+	// compute takes any func(float64, float64) float64, made from
+	// others: math.Pow with its arguments flipped, or hypot curried
+	// and uncurried back.
+	ctx.Println(compute(functional.Flip(math.Pow)))
+	ctx.Println(compute(functional.Uncurry(functional.Curry(hypot))))
+
+	// Curry binds the first argument: powers of two.
+	pow2 := functional.Curry(math.Pow)(2)
+	ctx.Println("Map(pow2):", functional.Map([]float64{0, 1, 2, 3, 10}, pow2))
+	ctx.Println("ZipWith(hypot):", functional.ZipWith([]float64{3, 5, 8}, []float64{4, 12, 15}, hypot))
+
+	primes := []int{2, 3, 5, 7, 11, 13}
+	odd := func(x int) bool { return x%2 == 1 }
+	ctx.Println("Filter(odd):", functional.Filter(primes, odd))
+	ctx.Println("Reduce(+):", functional.Reduce(primes, 0, func(sum, x int) int { return sum + x }))
+	yes, no := functional.Partition(primes, func(x int) bool { return x > 5 })
+	ctx.Println("Partition(> 5):", yes, no)
+
+	names := []string{"John", "Paul", "George", "Ringo"}
+	byLen := functional.GroupBy(names, func(s string) int { return len(s) })
+	ctx.Println("GroupBy(len):", byLen)
+	ctx.Println("Zip:", functional.Zip(names, primes))
+	shout := functional.Compose(strings.ToUpper, func(s string) string { return s + "!" })
+	ctx.Println("Map(Compose(ToUpper, !)):", functional.Map(names, shout))
+
+	// The closure of fibonacciClosure, memoized: each number is
+	// computed once.
+	calls := 0
+	var fib func(int) int
+	fib = functional.Memoize(func(n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	ctx.Println("fib(50):", fib(50), "in", calls, "calls")
 }
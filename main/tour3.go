@@ -1,176 +1,376 @@
 package main
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"golang.org/x/tour/pic"
+	"golearning/cipher"
+	"golearning/errs"
+	"golearning/geometry"
+	"golearning/inspect"
+	"golearning/numeric"
+	"golearning/readers"
 	"image"
 	"image/color"
+	"image/png"
 	"io"
 	"math"
-	"os"
+	"math/cmplx"
+	"math/rand"
+	"sort"
 	"strings"
+	"testing/iotest"
 	"time"
 )
 
 // Methods and interfaces
-func main() {
+var methods = lesson{
+	name:  "methods",
+	title: "Methods and interfaces",
+	steps: []step{
+		{"methods", methodsAndPointerIndirection},
+		{"vectors", vectors},
+		{"interfaces", interfaces},
+		{"nilInterfaceValues", nilInterfaceValues},
+		{"emptyInterface", emptyInterface},
+		{"typeAssertions", typeAssertions},
+		{"typeSwitches", typeSwitches},
+		{"stringers", stringers},
+		{"errors", errorsExample},
+		{"errs", structuredErrors},
+		{"readers", readersExample},
+		{"inspect", inspectReaders},
+		{"readers2", readerBlocks},
+		{"ciphers", ciphers},
+		{"images", images},
+	},
+}
+
+func methodsAndPointerIndirection(ctx *Context) {
 	// Methods are functions
 	// + Pointer indirection
 	v := Vertex3{3, 4}
 	var pV = &v
-	fmt.Println("v := Vertex3{3, 4}")
-	fmt.Println("var pV = &v")
+	ctx.Println("v := Vertex3{3, 4}")
+	ctx.Println("var pV = &v")
 	// If a method is expecting a value as a receiver
 	// you can pass a pointer to that value
-	fmt.Println("v.Abs():", v.Abs())
-	fmt.Println("pV.Abs():", pV.Abs())
+	ctx.Println("v.Abs():", v.Abs())
+	ctx.Println("pV.Abs():", pV.Abs())
 	// If a method is expecting a value as argument
 	// you cannot pass a pointer to that value
-	fmt.Println("AbsFunc(v):", AbsFunc(v))
-	fmt.Println("AbsFunc(pV): compile error")
+	ctx.Println("AbsFunc(v):", AbsFunc(v))
+	ctx.Println("AbsFunc(pV): compile error")
 
 	// Methods continued
 	f := MyFloat(-math.Sqrt2)
-	fmt.Println("f := MyFloat(-math.Sqrt2)")
-	fmt.Println("f.Abs():", f.Abs())
+	ctx.Println("f := MyFloat(-math.Sqrt2)")
+	ctx.Println("f.Abs():", f.Abs())
 
 	// Pointer receivers
 	v.Scale(10)
-	fmt.Println("v.Scale(10)")
-	fmt.Println("v.Abs():", v.Abs())
+	ctx.Println("v.Scale(10)")
+	ctx.Println("v.Abs():", v.Abs())
 	pV.Scale(10)
-	fmt.Println("pV.Scale(10)")
-	fmt.Println("v.Abs():", v.Abs())
+	ctx.Println("pV.Scale(10)")
+	ctx.Println("v.Abs():", v.Abs())
 	ScaleFunc(pV, 10) // == ScaleFunc(&v, 10)
-	fmt.Println("ScaleFunc(pV, 10)")
+	ctx.Println("ScaleFunc(pV, 10)")
 	// ScaleFunc(v, 10)
-	fmt.Println("ScaleFunc(v, 10) --> Not done, will compile error")
-	fmt.Println("v.Abs():", v.Abs())
+	ctx.Println("ScaleFunc(v, 10) --> Not done, will compile error")
+	ctx.Println("v.Abs():", v.Abs())
+}
 
+// Vertex3 grown into the vectors of "golearning/geometry"
+func vectors(ctx *Context) {
+	// Vertex3 has the same fields as a Vec2[float64], so it
+	// converts to one, and Vertex of the collections lesson to
+	// a Vec2[int].
+	v, w := geometry.Vec2[float64](Vertex3{3, 4}), geometry.Vec2[float64](Vertex3{1, -2})
+	ctx.Println("v, w:", v, w)
+	ctx.Println("v.Add(w), v.Sub(w), v.Mul(2):", v.Add(w), v.Sub(w), v.Mul(2))
+	ctx.Println("v.Dot(w), v.Cross(w), v.Dist(w):", v.Dot(w), v.Cross(w), v.Dist(w))
+	ctx.Println("v.Normalize(), v.Lerp(w, 0.5):", v.Normalize(), v.Lerp(w, 0.5))
+	ctx.Printf("v.Rotate(π/2): %.3g\n", v.Rotate(math.Pi/2))
+	ctx.Printf("v.AngleTo(w): %.4f rad\n", v.AngleTo(w))
+
+	p := geometry.Vec2[int](Vertex{1, 2})
+	ctx.Println("geometry.Vec2[int](Vertex{1, 2}).Mul(3):", p.Mul(3))
+
+	x, y := geometry.V3(1, 0, 0), geometry.V3(0, 1, 0)
+	ctx.Println("x.Cross(y):", x.Cross(y))
+	ctx.Printf("x.Rotate(x.Cross(y), π/2): %.3g\n", x.Rotate(x.Cross(y), math.Pi/2))
+
+	// Matrices transform vectors: rotate, then scale, then translate.
+	m := geometry.Translation2(10, 0).Mul(geometry.Affine2(geometry.Scaling2(2, 2).Mul(geometry.Rotation2(math.Pi / 2))))
+	ctx.Printf("m.Transform(v): %.3g\n", m.Transform(v))
+	data, _ := json.Marshal(v)
+	ctx.Printf("json.Marshal(v): %s\n", data)
+}
+
+func interfaces(ctx *Context) {
 	// Interface
 	//   A value of interface type can hold any value that implements
 	//   those methods.
 	var a Abser
-	fmt.Println("var a Abser")
+	ctx.Println("var a Abser")
 	f2 := MyFloat(-math.Sqrt2)
 	v2 := Vertex3{3, 4}
 
 	a = f2 // a MyFloat implements Abser
-	fmt.Println("a = f (MyFloat)")
+	ctx.Println("a = f (MyFloat)")
 	a = &v2 // a *Vertex implements Abser
-	fmt.Println("a = &v (*Vertex)")
+	ctx.Println("a = &v (*Vertex)")
 	// a = v2
-	fmt.Println("a = v (Vertex) --> not working")
-	fmt.Println("a.Abs():", a.Abs())
+	ctx.Println("a = v (Vertex) --> not working")
+	ctx.Println("a.Abs():", a.Abs())
 
 	// Interface implemented implicitly, values
 	var i I = T{"hello"}
-	fmt.Println("var i I = T{\"hello\"}")
+	ctx.Println("var i I = T{\"hello\"}")
 	// Calling a method on an interface value executes
 	// the method of the same name on its underlying type.
-	fmt.Println("i.M():")
-	i.M()
+	ctx.Println("i.M():")
+	i.M(ctx)
+}
 
+func nilInterfaceValues(ctx *Context) {
 	// Interface values with nil underlying values
 	var i2 I
-	// calling i2.M() here will result in a run-time error
+	// calling i2.M(ctx) here will result in a run-time error
 	var t *T2
 	i2 = t
-	fmt.Println("var i2 I")
-	fmt.Println("var t *T2")
-	fmt.Println("i2 = t")
-	fmt.Println("describe(i2):")
-	describe(i2)
-	fmt.Println("i2.M():")
-	i2.M() // would be an error without method M() for type T2
+	ctx.Println("var i2 I")
+	ctx.Println("var t *T2")
+	ctx.Println("i2 = t")
+	ctx.Println("describe(i2):")
+	describe(ctx, i2)
+	ctx.Println("i2.M():")
+	i2.M(ctx) // would be an error without method M() for type T2
 	i2 = &T2{"hello you"}
-	fmt.Println("i2 = &T{\"hello you\"}")
-	fmt.Println("describe(i2):")
-	describe(i2)
-	fmt.Println("i2.M():")
-	i2.M()
+	ctx.Println("i2 = &T{\"hello you\"}")
+	ctx.Println("describe(i2):")
+	describe(ctx, i2)
+	ctx.Println("i2.M():")
+	i2.M(ctx)
+}
 
+func emptyInterface(ctx *Context) {
 	// The empty interface
 	var i3 interface{}
-	fmt.Println("var i3 interface{}")
-	fmt.Println("describeAny(i3):")
-	describeAny(i3)
+	ctx.Println("var i3 interface{}")
+	ctx.Println("describeAny(i3):")
+	describeAny(ctx, i3)
 	i3 = 42
-	fmt.Println("i3 = 42")
-	fmt.Println("describeAny(i3):")
-	describeAny(i3)
+	ctx.Println("i3 = 42")
+	ctx.Println("describeAny(i3):")
+	describeAny(ctx, i3)
 	i3 = "hello"
-	fmt.Println("i3 = \"hello\"")
-	fmt.Println("describeAny(i3):")
-	describeAny(i3)
+	ctx.Println("i3 = \"hello\"")
+	ctx.Println("describeAny(i3):")
+	describeAny(ctx, i3)
+}
 
+func typeAssertions(ctx *Context) {
 	// Type assertions
 	var i4 interface{} = "hello"
-	fmt.Println("var i4 interface{} = \"hello\"")
+	ctx.Println("var i4 interface{} = \"hello\"")
 	s4 := i4.(string)
-	fmt.Println("s4 := i4.(string)")
-	fmt.Println("s4:", s4)
+	ctx.Println("s4 := i4.(string)")
+	ctx.Println("s4:", s4)
 	s4, ok := i4.(string)
-	fmt.Println("s, ok := i4.(string)")
-	fmt.Println("s:", s4, "| ok:", ok)
+	ctx.Println("s, ok := i4.(string)")
+	ctx.Println("s:", s4, "| ok:", ok)
 	f4, ok := i4.(float64)
-	fmt.Println("f4 ok := i4.(float64)")
-	fmt.Println("f4:", f4, "| ok:", ok)
-	fmt.Println("f4 = i.(float64) --> will trigger a panic")
+	ctx.Println("f4 ok := i4.(float64)")
+	ctx.Println("f4:", f4, "| ok:", ok)
+	ctx.Println("f4 = i.(float64) --> will trigger a panic")
+}
 
+func typeSwitches(ctx *Context) {
 	// Type switches (see method)
-	do(21)
-	do("hello")
-	do(true)
+	do(ctx, 21)
+	do(ctx, "hello")
+	do(ctx, true)
+}
 
+func stringers(ctx *Context) {
 	// Stringer (the interface for String() method)
 	hosts := map[string]IPAddr{
 		"loopback":  {127, 0, 0, 1},
 		"googleDNS": {8, 8, 8, 8},
 	}
-	for name, ip := range hosts {
-		fmt.Printf("%v: %v\n", name, ip)
+	// Iterating over a map has no fixed order,
+	// so range over its sorted keys instead.
+	names := make([]string, 0, len(hosts))
+	for name := range hosts {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	for _, name := range names {
+		ctx.Printf("%v: %v\n", name, hosts[name])
+	}
+}
 
+func errorsExample(ctx *Context) {
 	// Errors
-	if err := run(); err != nil {
-		fmt.Println(err)
+	if err := run(ctx.clock); err != nil {
+		ctx.Println(err)
 	}
 	r, e := Sqrt(2)
-	fmt.Printf("Sqrt(2): %v | %v\n", r, e)
+	ctx.Printf("Sqrt(2): %v | %v\n", r, e)
 	r, e = Sqrt(-2)
-	fmt.Printf("Sqrt(-2): %v | %v\n", r, e)
+	ctx.Printf("Sqrt(-2): %v | %v\n", r, e)
+
+	// errors.As finds the ErrNegativeSqrt even once wrapped,
+	// and its Complex method gives the root Sqrt could not.
+	e = fmt.Errorf("solving x*x + 2 = 0: %w", e)
+	ctx.Println(e)
+	var negative ErrNegativeSqrt
+	if errors.As(e, &negative) {
+		ctx.Printf("errors.As(e, &negative): %v | negative.Complex(): %v\n", float64(negative), negative.Complex())
+	}
+}
+
+// displayReader generalized in the "golearning/inspect" package
+func inspectReaders(ctx *Context) {
+	r := &inspect.Reader{R: strings.NewReader("Hello, Reader!"), Log: ctx, Now: ctx.clock.Now}
+	ctx.Println("r.Read(make([]byte, 16)) until an error")
+	b := make([]byte, 16)
+	for {
+		if _, err := r.Read(b); err != nil {
+			break
+		}
+	}
+
+	// A reader may return fewer bytes than asked, even before
+	// the end, and may return bytes along with io.EOF.
+	r = &inspect.Reader{
+		R:    iotest.HalfReader(iotest.DataErrReader(strings.NewReader("Hello, Reader!"))),
+		Log:  ctx,
+		Dump: true,
+		Now:  ctx.clock.Now,
+	}
+	ctx.Println("r.Read(make([]byte, 8)) until an error")
+	b = make([]byte, 8)
+	for {
+		if _, err := r.Read(b); err != nil {
+			break
+		}
+	}
+}
+
+// MyReader generalized in the "golearning/readers" package
+func readerBlocks(ctx *Context) {
+	displayReader2(ctx, readers.Repeat("Go"), make([]byte, 5))
+
+	// Readers compose: each one wraps another.
+	counter := &readers.Counter{R: readers.Upper(strings.NewReader("Hello, Reader!"))}
+	displayReader(ctx, readers.Chunking(counter, rand.New(rand.NewSource(1)), 6), make([]byte, 8))
+	ctx.Printf("counter.Bytes = %d, counter.Calls = %d\n", counter.Bytes, counter.Calls)
+
+	r := readers.FailAfter(readers.Repeat("A"), 12, nil)
+	b, err := io.ReadAll(r)
+	ctx.Printf("io.ReadAll(FailAfter(Repeat(A), 12)): %q | %v\n", b, err)
+}
+
+// rot13Reader generalized in the "golearning/cipher" package
+func ciphers(ctx *Context) {
+	s := strings.NewReader("Lbh penpxrq gur pbqr!")
+	io.Copy(ctx, cipher.NewReader(s, cipher.ROT13.Encoder()))
+	ctx.Println()
+
+	// The readers and writers map runes, not bytes,
+	// even when a rune is cut between two reads.
+	vigenere, _ := cipher.NewVigenere("lemon")
+	for _, c := range []struct {
+		name string
+		c    cipher.Cipher
+	}{
+		{"Caesar(3)", cipher.Caesar(3)},
+		{"ROT47", cipher.ROT47},
+		{"Atbash", cipher.Atbash},
+		{"Vigenère(lemon)", vigenere},
+	} {
+		encoded, _ := io.ReadAll(cipher.NewReader(iotest.OneByteReader(strings.NewReader("Attack at dawn, café!")), c.c.Encoder()))
+		ctx.Printf("%-16s %s -> ", c.name, encoded)
+		w := cipher.NewWriter(ctx, c.c.Decoder())
+		w.Write(encoded)
+		w.Close()
+		ctx.Println()
+	}
+}
+
+// Structured errors, from the "golearning/errs" package
+func structuredErrors(ctx *Context) {
+	// Unlike a MyError, an errs.Error has a code to test for.
+	err := run(ctx.clock)
+	ctx.Println("errors.Is(run(), errs.Internal):", errors.Is(err, errs.Internal))
+	_, err = Sqrt(-2)
+	ctx.Printf("Sqrt(-2): %v [%v]\n", err, errs.CodeOf(err))
+	data, _ := errs.JSON(err)
+	ctx.Printf("errs.JSON: %s\n", data)
+
+	// A List gathers several errors, and errors.Is and errors.As
+	// look into each of them.
+	var list errs.List
+	for _, x := range []float64{4, -1, 9, -3} {
+		if _, err := Sqrt(x); err != nil {
+			list = list.Append(err)
+		}
+	}
+	list = list.Append(errs.Errorf(errs.NotFound, "no root for %q", "x"))
+	err = list.Err()
+	ctx.Println("list.Err():", err)
+	ctx.Println("errors.Is(err, errs.NotFound):", errors.Is(err, errs.NotFound))
+	var negative ErrNegativeSqrt
+	ctx.Println("errors.As(err, &negative):", errors.As(err, &negative), float64(negative))
+	data, _ = errs.JSON(err)
+	ctx.Printf("errs.JSON: %s\n", data)
+
+	// With a captured stack, %+v and errs.JSON show the calls
+	// that led to the error.
+	err = errs.New(errs.Unavailable, "try again later").WithStack()
+	// The package path of the function depends on how the program
+	// was built: main, or the module for its tests.
+	caller := err.(*errs.Error).Stack()[0].Function
+	ctx.Println("caught in", caller[strings.LastIndex(caller, ".")+1:])
+}
 
+func readersExample(ctx *Context) {
 	// Readers
 	newReader := strings.NewReader("Hello, Reader!")
 	b := make([]byte, 8)
-	displayReader(newReader, b)
+	displayReader(ctx, newReader, b)
 
 	myReader := &MyReader{}
 	b = make([]byte, 10)
-	displayReader2(myReader, b)
+	displayReader2(ctx, myReader, b)
 
 	myOtherReader := &rot13Reader{r: strings.NewReader("Lbh penpxrq gur pbqr!")}
 	b = make([]byte, 20)
-	displayReader(io.Reader(myOtherReader), b)
+	displayReader(ctx, io.Reader(myOtherReader), b)
 
 	s := strings.NewReader("Lbh penpxrq gur pbqr!")
 	r2 := rot13Reader{s}
-	io.Copy(os.Stdout, &r2)
-	fmt.Println("")
+	io.Copy(ctx, &r2)
+	ctx.Println("")
+}
 
+func images(ctx *Context) {
 	// Images
 	m := image.NewRGBA(image.Rect(0, 0, 100, 100))
-	fmt.Println(m.Bounds())
-	fmt.Println(m.At(0, 0).RGBA())
+	ctx.Println(m.Bounds())
+	ctx.Println(m.At(0, 0).RGBA())
 
 	m2 := Image{Width: 100, Height: 100} // Set the width and height
-	fmt.Println("m2 := Image{Width: 100, Height: 100}")
-	fmt.Println("m2:", m2)
-	fmt.Println("m2.Bounds()", m2.Bounds())
-	fmt.Println("m2.ColorModel():", m2.ColorModel())
-	fmt.Println("m2.At(10, 10):", m2.At(10, 10))
-	pic.ShowImage(m2) /* from "golang.org/x/tour/pic" */
+	ctx.Println("m2 := Image{Width: 100, Height: 100}")
+	ctx.Println("m2:", m2)
+	ctx.Println("m2.Bounds()", m2.Bounds())
+	ctx.Println("m2.ColorModel() == color.RGBAModel:", m2.ColorModel() == color.RGBAModel)
+	ctx.Println("m2.At(10, 10):", m2.At(10, 10))
+	showImage(ctx, m2)
 }
 
 type Vertex3 struct {
@@ -229,7 +429,7 @@ There is no explicit declaration of intent, no "implements"
 keyword.
 */
 type I interface {
-	M()
+	M(ctx *Context)
 }
 
 type T struct {
@@ -238,46 +438,46 @@ type T struct {
 
 // This method means type T implements the interface I,
 // but we don't need to explicitly declare that it does so.
-func (t T) M() {
-	fmt.Println(t.S)
+func (t T) M(ctx *Context) {
+	ctx.Println(t.S)
 }
 
 type T2 struct {
 	S string
 }
 
-func (t *T2) M() {
+func (t *T2) M(ctx *Context) {
 	// cannot use == nil if was using 'T2' value
 	if t == nil {
-		fmt.Println("<nil>")
+		ctx.Println("<nil>")
 		return
 	}
-	fmt.Println(t.S)
+	ctx.Println(t.S)
 }
 
-func describe(i I) {
-	fmt.Printf("(%v, %T)\n", i, i)
+func describe(ctx *Context, i I) {
+	ctx.Printf("(%v, %T)\n", i, i)
 }
 
 /*
 An empty interface may hold values of any type.
 */
-func describeAny(i interface{}) {
-	fmt.Printf("(%v, %T)\n", i, i)
+func describeAny(ctx *Context, i interface{}) {
+	ctx.Printf("(%v, %T)\n", i, i)
 }
 
 /*
 A type switch is a construct that permits several
 type assertions in series.
 */
-func do(i interface{}) {
+func do(ctx *Context, i interface{}) {
 	switch v := i.(type) {
 	case int:
-		fmt.Printf("Twice %v is %v\n", v, v*2)
+		ctx.Printf("Twice %v is %v\n", v, v*2)
 	case string:
-		fmt.Printf("%q is %v bytes long\n", v, len(v))
+		ctx.Printf("%q is %v bytes long\n", v, len(v))
 	default:
-		fmt.Printf("I don't know about type %T!\n", v)
+		ctx.Printf("I don't know about type %T!\n", v)
 	}
 }
 
@@ -296,6 +496,9 @@ fmt.Stringer:
 	type error interface {
 	    Error() string
 	}
+
+MyError is the error run returned before the errs package: a time
+and a message, with nothing for errors.Is to match.
 */
 type MyError struct {
 	When time.Time
@@ -307,28 +510,51 @@ func (e *MyError) Error() string {
 		e.When, e.What)
 }
 
-func run() error {
-	return &MyError{
-		time.Now(),
-		"it didn't work",
-	}
+// MyError grew into errs.Error, which adds a code, the operation
+// that failed and the error it wraps.
+func run(clock Clock) error {
+	return errs.New(errs.Internal, "it didn't work").At(clock.Now())
+}
+
+// ErrNegativeSqrt is the error returned by Sqrt for a negative number.
+type ErrNegativeSqrt float64
+
+func (e ErrNegativeSqrt) Error() string {
+	// float64(e): with e itself, Sprint would call e.Error() again.
+	return fmt.Sprintf("cannot Sqrt negative number: %v", float64(e))
 }
 
+// Complex returns the square root of the number as a complex number,
+// the result Sqrt could not give.
+func (e ErrNegativeSqrt) Complex() complex128 {
+	return cmplx.Sqrt(complex(float64(e), 0))
+}
+
+// Sqrt returns the square root of x, found with the Newton
+// iteration of sqrtFinder run until z stops changing, or
+// ErrNegativeSqrt if x is negative.
 func Sqrt(x float64) (float64, error) {
-	if x >= 0 {
-		return 0, nil
+	switch {
+	case x < 0:
+		return 0, errs.Wrap(ErrNegativeSqrt(x), errs.Invalid, "Sqrt")
+	case x == 0 || math.IsInf(x, 1) || math.IsNaN(x):
+		return x, nil
 	}
-	return 0, &MyError{
-		time.Now(),
-		fmt.Sprintf("\ncannot Sqrt negative number: %v", x),
+	// Iterate on the mantissa, in [0.5, 2), whatever the magnitude
+	// of x: x = m·2^(2k), and its root is √m·2^k.
+	m, exp := math.Frexp(x)
+	if exp%2 != 0 {
+		m, exp = 2*m, exp-1
 	}
+	r, err := newtonSqrt(m, &numeric.Options{Tolerance: -1})
+	return math.Ldexp(r.Root, exp/2), err
 }
 
-func displayReader(reader io.Reader, b []byte) {
+func displayReader(ctx *Context, reader io.Reader, b []byte) {
 	for {
 		n, err := reader.Read(b)
-		fmt.Printf("n = %v err = %v b = %v\n", n, err, b)
-		fmt.Printf("b[:n] = %q\n", b[:n])
+		ctx.Printf("n = %v err = %v b = %v\n", n, err, b)
+		ctx.Printf("b[:n] = %q\n", b[:n])
 		if err == io.EOF {
 			break
 		}
@@ -344,10 +570,11 @@ func (m MyReader) Read(b []byte) (i int, e error) {
 	return len(b), nil
 }
 
-func displayReader2(myReader *MyReader, b []byte) {
-	n, err := myReader.Read(b)
-	fmt.Printf("n = %v err = %v b = %v\n", n, err, b)
-	fmt.Printf("b[:n] = %q\n", b[:n])
+// displayReader2 makes a single Read call, for readers that never end.
+func displayReader2(ctx *Context, reader io.Reader, b []byte) {
+	n, err := reader.Read(b)
+	ctx.Printf("n = %v err = %v b = %v\n", n, err, b)
+	ctx.Printf("b[:n] = %q\n", b[:n])
 }
 
 type rot13Reader struct {
@@ -397,3 +624,16 @@ func (img Image) At(x, y int) color.Color {
 	v := uint8((x + y) / 2)
 	return color.RGBA{v, v, 255, 255}
 }
+
+// showImage prints m the way pic.ShowImage from
+// "golang.org/x/tour/pic" does, but to ctx instead of os.Stdout.
+func showImage(ctx *Context, m image.Image) {
+	ctx.Print("IMAGE:")
+	b64 := base64.NewEncoder(base64.StdEncoding, ctx)
+	err := (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(b64, m)
+	if err != nil {
+		panic(err)
+	}
+	b64.Close()
+	ctx.Println()
+}
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"fmt"
-	"golang.org/x/tour/tree"
+	"golearning/bst"
+	"golearning/crawler"
+	"golearning/gallery"
+	"golearning/render"
+	"golearning/tree"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,14 +21,37 @@ import (
 //    Buffered Channels
 //    Range and Close
 //    Select
+//    sync.Mutex
+
+var concurrency = lesson{
+	name:  "concurrency",
+	title: "Goroutines, Channels, Buffered Channels, Range and Close, Select",
+	steps: []step{
+		{"say", goroutines},
+		{"sum", channels},
+		{"bufferedChannels", bufferedChannels},
+		{"fibonacci4", rangeAndClose},
+		{"fibonacci5", selectStatement},
+		{"printTreeComplexImproved", printTestTree},
+		{"Draw", drawTrees},
+		{"ParseLevels", parseTrees},
+		{"bst", balancedTrees},
+		{"Same", equivalentBinaryTrees},
+		{"SafeCounter", mutexCounter},
+		{"Crawl", webCrawler},
+		{"Tiled", tiledRendering},
+	},
+}
 
-func main() {
+func goroutines(ctx *Context) {
 	// A Goroutines is a lightweight thread managed
 	// by the Go runtime.
 	// starts a new goroutine running
-	go say("world")
-	say("hello")
+	go say(ctx, "world")
+	say(ctx, "hello")
+}
 
+func channels(ctx *Context) {
 	// Channels are a typed conduit through which you
 	// can send and receive values with the channel operator, <-.
 	s := []int{7, 2, 8, -9, 4, 0}
@@ -29,21 +59,25 @@ func main() {
 	go sum(s[:len(s)/2], c)
 	go sum(s[len(s)/2:], c)
 	x, y := <-c, <-c // receive from c
-	fmt.Println("s := []int{7, 2, 8, -9, 4, 0}")
-	fmt.Println("c := make(chan int)")
-	fmt.Println("go sum(s[:len(s)/2], c)")
-	fmt.Println("go sum(s[len(s)/2:], c)")
-	fmt.Println("x, y := <-c, <-c ")
-	fmt.Println("x, y, x+y:", x, y, x+y)
+	ctx.Println("s := []int{7, 2, 8, -9, 4, 0}")
+	ctx.Println("c := make(chan int)")
+	ctx.Println("go sum(s[:len(s)/2], c)")
+	ctx.Println("go sum(s[len(s)/2:], c)")
+	ctx.Println("x, y := <-c, <-c ")
+	ctx.Println("x, y, x+y:", x, y, x+y)
+}
 
+func bufferedChannels(ctx *Context) {
 	// Buffered Channels
 	ch := make(chan int, 2)
 	ch <- 1
 	ch <- 2
 	// ch <- 3  // fatal error: all goroutines are asleep - deadlock!
-	fmt.Println(<-ch)
-	fmt.Println(<-ch)
+	ctx.Println(<-ch)
+	ctx.Println(<-ch)
+}
 
+func rangeAndClose(ctx *Context) {
 	// Range and Close
 	/*
 		A sender can close a channel to indicate that no more values will be sent.
@@ -52,13 +86,15 @@ func main() {
 	*/
 	cha := make(chan int, 10)
 	go fibonacci4(cap(cha), cha)
-	fmt.Println("go fibonacci4(cap(cha), cha)")
+	ctx.Println("go fibonacci4(cap(cha), cha)")
 	// The loop for i := range c receives values from the channel repeatedly until
 	// it is closed.
 	for i := range cha {
-		fmt.Println(i)
+		ctx.Println(i)
 	}
+}
 
+func selectStatement(ctx *Context) {
 	// Select
 	/*
 		The select statement lets a goroutine wait on multiple communication operations.
@@ -69,22 +105,63 @@ func main() {
 	quit := make(chan int)
 	go func() {
 		for i := 0; i < 10; i++ {
-			fmt.Println("<-c5:", <-c5)
+			ctx.Println("<-c5:", <-c5)
 			time.Sleep(500 * time.Millisecond)
 		}
-		fmt.Println("quit <- 0")
+		ctx.Println("quit <- 0")
 		quit <- 0
 	}()
-	fmt.Println("go fibonacci5(c5, quit)")
-	fibonacci5(c5, quit)
+	ctx.Println("go fibonacci5(c5, quit)")
+	fibonacci5(ctx, c5, quit)
 
 	// Default Selection (see fibonacci5)
+}
+
+// Exercise: Equivalent Binary Trees
+// using "golang.org/x/tour/tree" (through "golearning/tree")
+func printTestTree(ctx *Context) {
+	printTreeComplexImproved(ctx, testTree())
+}
+
+func drawTrees(ctx *Context) {
+	ctx.Println("tree.Draw(testTree())")
+	tree.Draw(ctx, testTree())
+	ctx.Println("tree.Draw(newTree(5))")
+	tree.Draw(ctx, newTree(ctx.rand, 5))
+}
 
-	// Exercise: Equivalent Binary Trees
-	// using "golang.org/x/tour/tree"
+func parseTrees(ctx *Context) {
+	// The notation printed by printTreeComplex can be read back.
+	levels := "1\n0 - 2\nnil - nil - nil - 3\nnil - nil\n"
+	t, err := tree.ParseLevels(levels)
+	ctx.Printf("tree.ParseLevels(%q): %v %v\n", levels, t, err)
+	data, _ := tree.FormatJSON(t)
+	ctx.Printf("tree.FormatJSON(t): %s\n", data)
+	t, err = tree.ParseJSON([]byte("[4,2,6,1,3,5,7]"))
+	ctx.Println("tree.ParseJSON([4,2,6,1,3,5,7]):", t, err)
+	tree.Draw(ctx, t)
+	ctx.Print("tree.FormatLevels(t):\n", tree.FormatLevels(t))
+	_, err = tree.ParseLevels("1 - nil - nil - 2")
+	ctx.Println("tree.ParseLevels(\"1 - nil - nil - 2\"):", err)
+}
 
+// Search trees that stay balanced are in the "golearning/bst" package.
+func balancedTrees(ctx *Context) {
+	other := newTree(ctx.rand, 5)
+	for _, b := range []bst.Balance{bst.Plain, bst.AVL, bst.RedBlack} {
+		t := bst.New[int](b)
+		for i := 1; i <= 10; i++ {
+			t.Insert(i * 5)
+		}
+		ctx.Printf("%v tree of 5, 10, ..., 50 inserted in order (height %d):\n", b, t.Height())
+		tree.Draw(ctx, bst.TourTree(t))
+		ctx.Println("Same(t, newTree(5)):", tree.Same(bst.TourTree(t), other))
+	}
+}
+
+func testTree() *tree.Tree {
 	// test
-	root := &tree.Tree{
+	return &tree.Tree{
 		Value: 1,
 		Left: &tree.Tree{
 			Value: 0,
@@ -96,32 +173,115 @@ func main() {
 			},
 		},
 	}
-	printTreeComplexImproved(root)
-
-	tree1 := tree.New(5)
-	tree2 := tree.New(5)
-	fmt.Println("printTreeComplexImproved(tree1)")
-	printTreeComplexImproved(tree1)
-	fmt.Println("printTreeComplexImproved(tree2)")
-	printTreeComplexImproved(tree2)
-	fmt.Println("Same(tree1, tree2):", Same(tree1, tree2))
-	defer testLast()
 }
 
-func testLast() {
-	tree1 := tree.New(5)
-	tree2 := tree.New(10)
-	fmt.Println("printTreeComplexImproved(tree1)")
-	printTreeComplexImproved(tree1)
-	fmt.Println("printTreeComplexImproved(tree2)")
-	printTreeComplexImproved(tree2)
-	fmt.Println("Same(tree1, tree2):", Same(tree1, tree2))
+// Walk and Same are in the "golearning/tree" package.
+func equivalentBinaryTrees(ctx *Context) {
+	tree1 := newTree(ctx.rand, 5)
+	tree2 := newTree(ctx.rand, 5)
+	ctx.Println("printTreeComplexImproved(tree1)")
+	printTreeComplexImproved(ctx, tree1)
+	ctx.Println("printTreeComplexImproved(tree2)")
+	printTreeComplexImproved(ctx, tree2)
+	printWalk(ctx, "tree1", tree1)
+	printWalk(ctx, "tree2", tree2)
+	ctx.Println("Same(tree1, tree2):", tree.Same(tree1, tree2))
+	defer testLast(ctx)
+}
+
+func testLast(ctx *Context) {
+	tree1 := newTree(ctx.rand, 5)
+	tree2 := newTree(ctx.rand, 10)
+	ctx.Println("printTreeComplexImproved(tree1)")
+	printTreeComplexImproved(ctx, tree1)
+	ctx.Println("printTreeComplexImproved(tree2)")
+	printTreeComplexImproved(ctx, tree2)
+	printWalk(ctx, "tree1", tree1)
+	printWalk(ctx, "tree2", tree2)
+	ctx.Println("Same(tree1, tree2):", tree.Same(tree1, tree2))
+}
+
+// SafeCounter is safe to use concurrently.
+type SafeCounter struct {
+	mu sync.Mutex
+	v  map[string]int
+}
+
+// Inc increments the counter for the given key.
+func (c *SafeCounter) Inc(key string) {
+	c.mu.Lock()
+	// Lock so only one goroutine at a time can access the map c.v.
+	c.v[key]++
+	c.mu.Unlock()
+}
+
+// Value returns the current value of the counter for the given key.
+func (c *SafeCounter) Value(key string) int {
+	c.mu.Lock()
+	// Lock so only one goroutine at a time can access the map c.v.
+	defer c.mu.Unlock()
+	return c.v[key]
+}
+
+func mutexCounter(ctx *Context) {
+	// A sync.Mutex makes sure only one goroutine can access
+	// a variable at a time, to avoid conflicts.
+	c := SafeCounter{v: make(map[string]int)}
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Inc("somekey")
+		}()
+	}
+	wg.Wait()
+	ctx.Println("c.Value(\"somekey\"):", c.Value("somekey"))
+}
+
+// Exercise: Web Crawler
+// Crawl and the fakeFetcher of the tour are in the "golearning/crawler" package.
+func webCrawler(ctx *Context) {
+	ctx.Println("crawler.Crawl(\"https://golang.org/\", 4, crawler.TourFetcher)")
+	for _, r := range crawler.Crawl("https://golang.org/", 4, crawler.TourFetcher) {
+		if r.Err != nil {
+			ctx.Println(r.Err)
+			continue
+		}
+		ctx.Printf("found: %s %q\n", r.URL, r.Body)
+	}
+}
+
+// Rendering an image in parallel: the pixels of a tile do not
+// depend on the other tiles, so the tiles can be computed by
+// several goroutines, each writing its own part of the image.
+func tiledRendering(ctx *Context) {
+	m := gallery.Mandelbrot{Canvas: gallery.Canvas{Width: 200, Height: 150}}
+	tiles := render.Tiles(m.Bounds(), 64)
+	ctx.Printf("render.Tiles(%v, 64): %d tiles, from %v to %v\n", m.Bounds(), len(tiles), tiles[0], tiles[len(tiles)-1])
+	serial := render.Serial(m)
+	for _, workers := range []int{1, 2, 4, 8} {
+		tiled := render.Tiled(m, 64, workers)
+		ctx.Printf("render.Tiled(m, 64, %d) same as render.Serial(m): %v\n", workers, bytes.Equal(tiled.Pix, serial.Pix))
+	}
+}
+
+// printWalk prints the values Walk sends for the tree t.
+func printWalk(ctx *Context, name string, t *tree.Tree) {
+	ch := make(chan int)
+	go tree.Walk(context.Background(), t, ch)
+	ctx.Printf("Walk(%s):", name)
+	// Walk closes ch after the last value.
+	for v := range ch {
+		ctx.Print(" ", v)
+	}
+	ctx.Println()
 }
 
-func say(s string) {
+func say(ctx *Context, s string) {
 	for i := 0; i < 5; i++ {
 		time.Sleep(100 * time.Millisecond)
-		fmt.Println(s)
+		ctx.Println(s)
 	}
 }
 
@@ -150,87 +310,65 @@ func fibonacci4(n int, c chan int) {
 	close(c)
 }
 
-func fibonacci5(c, quit chan int) {
+func fibonacci5(ctx *Context, c, quit chan int) {
 	x, y := 0, 1
 	for {
 		select {
 		case c <- x:
-			fmt.Println("case c <- x: x, y = y, x+y")
+			ctx.Println("case c <- x: x, y = y, x+y")
 			x, y = y, x+y
 		case <-quit:
-			fmt.Println("<-quit: quitting method fibonacci5(c, quit)")
+			ctx.Println("<-quit: quitting method fibonacci5(c, quit)")
 			return
 		default:
-			fmt.Println("Waiting...")
+			ctx.Println("Waiting...")
 			time.Sleep(250 * time.Millisecond)
 		}
 	}
 }
 
-/*
-type Tree struct {
-	Left  *Tree
-	Value int
-	Right *Tree
+// newTree returns a random tree holding the values k, 2k, ..., 10k,
+// built the same way as tree.New but drawing from r so that
+// the tree can be reproduced.
+func newTree(r Random, k int) *tree.Tree {
+	var t *tree.Tree
+	for _, v := range r.Perm(10) {
+		t = insert(t, (1+v)*k)
+	}
+	return t
 }
-*/
 
-// Walk walks the tree t sending all values
-// from the tree to the channel ch.
-func Walk(t *tree.Tree, ch chan int) {
-	fmt.Println("Walk in a tree")
-	if t.Left != nil {
-		fmt.Println("going left")
-		Walk(t.Left, ch)
-	} else {
-		fmt.Println("take value")
-		ch <- t.Value
+func insert(t *tree.Tree, v int) *tree.Tree {
+	if t == nil {
+		return &tree.Tree{Value: v}
 	}
-	if t.Right != nil {
-		fmt.Println("going right")
-		Walk(t.Right, ch)
+	if v < t.Value {
+		t.Left = insert(t.Left, v)
+	} else {
+		t.Right = insert(t.Right, v)
 	}
+	return t
 }
 
-// Same determines whether the trees
-// t1 and t2 contain the same values.
-func Same(t1, t2 *tree.Tree) bool {
-	fmt.Println("Same() method start")
-	c1 := make(chan int)
-	c2 := make(chan int)
-	go Walk(t1, c1)
-	go Walk(t2, c2)
-	for {
-		select {
-		case x := <-c1:
-			y := <-c2
-			fmt.Printf("x := <-c1 (x=%v) y := <-c2 (y=%v)\n", x, y)
-			if x != y {
-				return false
-			}
-		case <-c1:
-			fmt.Println("<-c1 Same() method end")
-			return true
-		}
-	}
+/*
+type Tree struct {
+	Left  *Tree
+	Value int
+	Right *Tree
 }
+*/
 
-func printTree(t *tree.Tree) {
+func printTree(ctx *Context, t *tree.Tree) {
 	if t == nil {
 		return
 	}
 
-	printTree(t.Left)    // Traverse left subtree
-	fmt.Println(t.Value) // Print the value
-	printTree(t.Right)   // Traverse right subtree
-}
-
-type NodeInfo struct {
-	Node   *tree.Tree
-	Parent int
+	printTree(ctx, t.Left)  // Traverse left subtree
+	ctx.Println(t.Value)    // Print the value
+	printTree(ctx, t.Right) // Traverse right subtree
 }
 
-func printTreeComplex(root *tree.Tree) {
+func printTreeComplex(ctx *Context, root *tree.Tree) {
 	if root == nil {
 		return
 	}
@@ -255,44 +393,26 @@ func printTreeComplex(root *tree.Tree) {
 			}
 		}
 
-		fmt.Println(strings.Join(currentLevel, " - "))
+		ctx.Println(strings.Join(currentLevel, " - "))
 	}
 }
 
-func printTreeComplexImproved(root *tree.Tree) {
-	if root == nil {
-		return
-	}
-
-	nodes := []NodeInfo{{Node: root, Parent: -1}} // -1 indicates no parent
-
-	for len(nodes) > 0 {
-		size := len(nodes)
+func printTreeComplexImproved(ctx *Context, root *tree.Tree) {
+	// see tree.Levels for the level by level traversal
+	for _, level := range tree.Levels(root) {
 		currentLevel := []string{}
 
-		for i := 0; i < size; i++ {
-			nodeInfo := nodes[0]
-			nodes = nodes[1:]
-
-			var nodeStr string
+		for _, nodeInfo := range level {
+			nodeStr := "nil"
 			if nodeInfo.Node != nil {
 				nodeStr = strconv.Itoa(nodeInfo.Node.Value)
-				if nodeInfo.Parent != -1 {
-					nodeStr = fmt.Sprintf("(%d) %s", nodeInfo.Parent, nodeStr)
-				}
-
-				nodes = append(nodes, NodeInfo{Node: nodeInfo.Node.Left, Parent: nodeInfo.Node.Value})
-				nodes = append(nodes, NodeInfo{Node: nodeInfo.Node.Right, Parent: nodeInfo.Node.Value})
-			} else {
-				if nodeInfo.Parent != -1 {
-					nodeStr = fmt.Sprintf("(%d) nil", nodeInfo.Parent)
-				} else {
-					nodeStr = "nil"
-				}
+			}
+			if nodeInfo.Parent != nil {
+				nodeStr = fmt.Sprintf("(%d) %s", nodeInfo.Parent.Value, nodeStr)
 			}
 			currentLevel = append(currentLevel, nodeStr)
 		}
 
-		fmt.Println(strings.Join(currentLevel, " - "))
+		ctx.Println(strings.Join(currentLevel, " - "))
 	}
 }
@@ -0,0 +1,92 @@
+package tree
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Draw writes an ASCII drawing of t to w, each node above
+// its subtrees with branches leading to them:
+//
+//	   __20_____
+//	  /         \
+//	 10_       35_
+//	/   \     /   \
+//	5  15    30  40
+//
+// Each subtree is given the width it needs, so that no
+// label overlaps whatever the depth of the tree.
+func Draw(w io.Writer, t *Tree) error {
+	if t == nil {
+		return nil
+	}
+	for _, line := range draw(t).lines {
+		if _, err := io.WriteString(w, strings.TrimRight(line, " ")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A block is the drawing of a subtree: lines of the same
+// width, and the column of the middle of the root's label.
+type block struct {
+	lines  []string
+	width  int
+	middle int
+}
+
+// draw lays out the subtrees of t side by side, as wide as
+// they need to be, with the label of t above them.
+func draw(t *Tree) block {
+	label := strconv.Itoa(t.Value)
+	u := len(label)
+	spaces := func(n int) string { return strings.Repeat(" ", n) }
+	underscores := func(n int) string { return strings.Repeat("_", n) }
+
+	switch {
+	case t.Left == nil && t.Right == nil:
+		return block{[]string{label}, u, u / 2}
+
+	case t.Right == nil:
+		l := draw(t.Left)
+		lines := []string{
+			spaces(l.middle+1) + underscores(l.width-l.middle-1) + label,
+			spaces(l.middle) + "/" + spaces(l.width-l.middle-1+u),
+		}
+		for _, line := range l.lines {
+			lines = append(lines, line+spaces(u))
+		}
+		return block{lines, l.width + u, l.width + u/2}
+
+	case t.Left == nil:
+		r := draw(t.Right)
+		lines := []string{
+			label + underscores(r.middle) + spaces(r.width-r.middle),
+			spaces(u+r.middle) + "\\" + spaces(r.width-r.middle-1),
+		}
+		for _, line := range r.lines {
+			lines = append(lines, spaces(u)+line)
+		}
+		return block{lines, r.width + u, u / 2}
+
+	default:
+		l, r := draw(t.Left), draw(t.Right)
+		lines := []string{
+			spaces(l.middle+1) + underscores(l.width-l.middle-1) + label + underscores(r.middle) + spaces(r.width-r.middle),
+			spaces(l.middle) + "/" + spaces(l.width-l.middle-1+u+r.middle) + "\\" + spaces(r.width-r.middle-1),
+		}
+		for i := 0; i < max(len(l.lines), len(r.lines)); i++ {
+			left, right := spaces(l.width), spaces(r.width)
+			if i < len(l.lines) {
+				left = l.lines[i]
+			}
+			if i < len(r.lines) {
+				right = r.lines[i]
+			}
+			lines = append(lines, left+spaces(u)+right)
+		}
+		return block{lines, l.width + u + r.width, l.width + u/2}
+	}
+}
@@ -0,0 +1,149 @@
+package tree
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// testTree is the tree of the concurrency lesson:
+//
+//	  1
+//	 / \
+//	0   2
+//	     \
+//	      3
+func testTree() *Tree {
+	return &Tree{
+		Value: 1,
+		Left:  &Tree{Value: 0},
+		Right: &Tree{Value: 2, Right: &Tree{Value: 3}},
+	}
+}
+
+func TestLevels(t *testing.T) {
+	var got []string
+	for _, level := range Levels(testTree()) {
+		var names []string
+		for _, n := range level {
+			name := "nil"
+			if n.Node != nil {
+				name = string(rune('0' + n.Node.Value))
+			}
+			if n.Parent != nil {
+				name = string(rune('0'+n.Parent.Value)) + ">" + name
+			}
+			names = append(names, name)
+		}
+		got = append(got, strings.Join(names, " "))
+	}
+	want := []string{
+		"1",
+		"1>0 1>2",
+		"0>nil 0>nil 2>nil 2>3",
+		"3>nil 3>nil",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Levels(testTree()) =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if levels := Levels(nil); levels != nil {
+		t.Errorf("Levels(nil) = %v, want nil", levels)
+	}
+}
+
+func TestDraw(t *testing.T) {
+	tests := []struct {
+		name string
+		t    *Tree
+		want string
+	}{
+		{"leaf", &Tree{Value: 42}, "42\n"},
+		{"test tree", testTree(), "" +
+			" 1\n" +
+			"/ \\\n" +
+			"0 2\n" +
+			"   \\\n" +
+			"   3\n"},
+		{"left spine", &Tree{Value: 100, Left: &Tree{Value: 5, Left: &Tree{Value: 1}}}, "" +
+			"  100\n" +
+			" /\n" +
+			" 5\n" +
+			"/\n" +
+			"1\n"},
+		{"wide labels", &Tree{Value: 20, Left: &Tree{Value: 10}, Right: &Tree{Value: 30}}, "" +
+			"  20_\n" +
+			" /   \\\n" +
+			"10  30\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := Draw(&b, tt.t); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("Draw(%s) =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDrawKeepsLabelsApart(t *testing.T) {
+	// The values 1..10 must all appear, whatever the shape.
+	for k := 1; k <= 20; k++ {
+		var b strings.Builder
+		Draw(&b, New(k*1000))
+		fields := strings.FieldsFunc(b.String(), func(r rune) bool {
+			return !('0' <= r && r <= '9')
+		})
+		if len(fields) != 10 {
+			t.Fatalf("Draw(New(%d)) shows %d labels, want 10:\n%s", k*1000, len(fields), b.String())
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var b strings.Builder
+	if err := WriteDOT(&b, testTree()); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph tree {
+	node [shape=circle];
+	n1 [label="1"];
+	n2 [label="0"];
+	n1 -> n2;
+	n3 [label="2"];
+	n1 -> n3;
+	n6 [style=invis];
+	n3 -> n6 [style=invis];
+	n7 [label="3"];
+	n3 -> n7;
+}
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteDOT(testTree()) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var b strings.Builder
+	if err := WriteSVG(&b, New(1)); err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	d := xml.NewDecoder(strings.NewReader(b.String()))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("WriteSVG wrote invalid XML: %v\n%s", err, b.String())
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			counts[start.Name.Local]++
+		}
+	}
+	if counts["svg"] != 1 || counts["circle"] != 10 || counts["text"] != 10 || counts["line"] != 9 {
+		t.Errorf("WriteSVG(New(1)) has %v elements, want 1 svg, 10 circles and texts, 9 lines", counts)
+	}
+}
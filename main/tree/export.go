@@ -0,0 +1,103 @@
+package tree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteDOT writes t to w as a Graphviz graph, to be rendered
+// with e.g. dot -Tpng. Nodes are named after their position
+// in Levels, and missing children are drawn as invisible
+// nodes so that a lone child stays on its side.
+func WriteDOT(w io.Writer, t *Tree) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph tree {")
+	fmt.Fprintln(bw, "\tnode [shape=circle];")
+	ids := make(map[*Tree]int)
+	id := 0
+	for _, level := range Levels(t) {
+		for _, n := range level {
+			id++
+			if n.Node != nil {
+				ids[n.Node] = id
+				fmt.Fprintf(bw, "\tn%d [label=\"%d\"];\n", id, n.Node.Value)
+			} else if n.Parent != nil && (n.Parent.Left != nil || n.Parent.Right != nil) {
+				fmt.Fprintf(bw, "\tn%d [style=invis];\n", id)
+			} else {
+				continue
+			}
+			if n.Parent != nil {
+				style := ""
+				if n.Node == nil {
+					style = " [style=invis]"
+				}
+				fmt.Fprintf(bw, "\tn%d -> n%d%s;\n", ids[n.Parent], id, style)
+			}
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// Geometry of the SVG drawing, in pixels.
+const (
+	svgRadius = 16
+	svgStepX  = 40 // between two consecutive values
+	svgStepY  = 56 // between two levels
+	svgMargin = 24
+)
+
+// WriteSVG writes a drawing of t to w as an SVG image. Each
+// node is placed in the column of its rank in the walk of
+// the tree, and in the row of its depth.
+func WriteSVG(w io.Writer, t *Tree) error {
+	type position struct{ x, y int }
+	positions := make(map[*Tree]position)
+	var order []*Tree
+	var place func(t *Tree, depth int)
+	place = func(t *Tree, depth int) {
+		if t == nil {
+			return
+		}
+		place(t.Left, depth+1)
+		positions[t] = position{
+			x: svgMargin + svgRadius + len(order)*svgStepX,
+			y: svgMargin + svgRadius + depth*svgStepY,
+		}
+		order = append(order, t)
+		place(t.Right, depth+1)
+	}
+	place(t, 0)
+
+	width, height := 2*svgMargin, 2*svgMargin
+	if len(order) > 0 {
+		width += 2*svgRadius + (len(order)-1)*svgStepX
+		height += 2*svgRadius + (len(Levels(t))-2)*svgStepY
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width, height, width, height)
+	fmt.Fprintln(bw, `<g stroke="black" stroke-width="1.5">`)
+	for _, n := range order {
+		p := positions[n]
+		for _, child := range []*Tree{n.Left, n.Right} {
+			if child != nil {
+				c := positions[child]
+				fmt.Fprintf(bw, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\"/>\n", p.x, p.y, c.x, c.y)
+			}
+		}
+	}
+	fmt.Fprintln(bw, "</g>")
+	fmt.Fprintln(bw, `<g font-family="monospace" font-size="12" text-anchor="middle" dominant-baseline="central">`)
+	for _, n := range order {
+		p := positions[n]
+		fmt.Fprintf(bw, "<circle cx=\"%d\" cy=\"%d\" r=\"%d\" fill=\"white\" stroke=\"black\" stroke-width=\"1.5\"/>\n",
+			p.x, p.y, svgRadius)
+		fmt.Fprintf(bw, "<text x=\"%d\" y=\"%d\">%d</text>\n", p.x, p.y, n.Value)
+	}
+	fmt.Fprintln(bw, "</g>")
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
@@ -0,0 +1,33 @@
+package tree
+
+// A NodeInfo is a node met by Levels, with its parent.
+// Node is nil for the missing children of a node.
+type NodeInfo struct {
+	Node   *Tree
+	Parent *Tree // nil for the root
+}
+
+// Levels returns the nodes of t level by level, from left to
+// right. The missing children of every node are kept as nil
+// nodes, so the last level only holds nil nodes.
+func Levels(t *Tree) [][]NodeInfo {
+	if t == nil {
+		return nil
+	}
+	var levels [][]NodeInfo
+	nodes := []NodeInfo{{Node: t}}
+	for len(nodes) > 0 {
+		levels = append(levels, nodes)
+		var next []NodeInfo
+		for _, n := range nodes {
+			if n.Node != nil {
+				next = append(next,
+					NodeInfo{Node: n.Node.Left, Parent: n.Node},
+					NodeInfo{Node: n.Node.Right, Parent: n.Node},
+				)
+			}
+		}
+		nodes = next
+	}
+	return levels
+}
@@ -0,0 +1,123 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatLevels returns t in the level-order notation printed
+// by printTreeComplex: one line per level, values separated
+// by " - ", with "nil" for the missing children of a node.
+//
+//	1
+//	0 - 2
+//	nil - nil - nil - 3
+//	nil - nil
+func FormatLevels(t *Tree) string {
+	var b strings.Builder
+	for _, level := range Levels(t) {
+		for i, n := range level {
+			if i > 0 {
+				b.WriteString(" - ")
+			}
+			if n.Node == nil {
+				b.WriteString("nil")
+			} else {
+				b.WriteString(strconv.Itoa(n.Node.Value))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ParseLevels parses the notation of FormatLevels. Line breaks
+// are optional, as are the trailing nil values: "1 - 0 - 2 -
+// nil - nil - nil - 3" is the same tree as the example of
+// FormatLevels. An empty string or "nil" is the nil tree.
+func ParseLevels(s string) (*Tree, error) {
+	var values []*int
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		for _, field := range strings.Split(line, " - ") {
+			field = strings.TrimSpace(field)
+			if field == "nil" {
+				values = append(values, nil)
+				continue
+			}
+			v, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("tree: invalid value %q", field)
+			}
+			values = append(values, &v)
+		}
+	}
+	return fromLevelOrder(values)
+}
+
+// FormatJSON returns t as a JSON array in level order, with
+// null for the missing children of a node and without the
+// trailing nulls, like LeetCode does: [1,0,2,null,null,null,3].
+func FormatJSON(t *Tree) ([]byte, error) {
+	var values []*int
+	for _, level := range Levels(t) {
+		for _, n := range level {
+			if n.Node == nil {
+				values = append(values, nil)
+			} else {
+				values = append(values, &n.Node.Value)
+			}
+		}
+	}
+	for len(values) > 0 && values[len(values)-1] == nil {
+		values = values[:len(values)-1]
+	}
+	if values == nil {
+		values = []*int{}
+	}
+	return json.Marshal(values)
+}
+
+// ParseJSON parses the notation of FormatJSON.
+func ParseJSON(data []byte) (*Tree, error) {
+	var values []*int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("tree: %w", err)
+	}
+	return fromLevelOrder(values)
+}
+
+// fromLevelOrder returns the tree of values given in level order, nil
+// standing for the missing children of a node.
+func fromLevelOrder(values []*int) (*Tree, error) {
+	if len(values) == 0 || values[0] == nil {
+		if len(values) > 1 {
+			return nil, fmt.Errorf("tree: %d values after a nil root", len(values)-1)
+		}
+		return nil, nil
+	}
+	root := &Tree{Value: *values[0]}
+	// parents holds the nodes whose children are still to be read.
+	parents := []*Tree{root}
+	for i := 1; i < len(values); i += 2 {
+		if len(parents) == 0 {
+			return nil, fmt.Errorf("tree: value %d of %d has no parent", i+1, len(values))
+		}
+		parent := parents[0]
+		parents = parents[1:]
+		if v := values[i]; v != nil {
+			parent.Left = &Tree{Value: *v}
+			parents = append(parents, parent.Left)
+		}
+		if i+1 < len(values) && values[i+1] != nil {
+			parent.Right = &Tree{Value: *values[i+1]}
+			parents = append(parents, parent.Right)
+		}
+	}
+	return root, nil
+}
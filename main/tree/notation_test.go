@@ -0,0 +1,82 @@
+package tree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	const levels = "1\n0 - 2\nnil - nil - nil - 3\nnil - nil\n"
+	if got := FormatLevels(testTree()); got != levels {
+		t.Errorf("FormatLevels(testTree()) = %q, want %q", got, levels)
+	}
+	const json = "[1,0,2,null,null,null,3]"
+	if got, err := FormatJSON(testTree()); err != nil || string(got) != json {
+		t.Errorf("FormatJSON(testTree()) = %s, %v, want %s", got, err, json)
+	}
+	if got, err := FormatJSON(nil); err != nil || string(got) != "[]" {
+		t.Errorf("FormatJSON(nil) = %s, %v, want []", got, err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *Tree
+	}{
+		{"1\n0 - 2\nnil - nil - nil - 3\nnil - nil\n", testTree()},
+		{"1 - 0 - 2 - nil - nil - nil - 3", testTree()},
+		{"  1\n\n0 - 2\r\nnil - nil - nil - 3 - nil", testTree()},
+		{"-1 - nil - -2", &Tree{Value: -1, Right: &Tree{Value: -2}}},
+		{"", nil},
+		{"nil", nil},
+	}
+	for _, tt := range tests {
+		got, err := ParseLevels(tt.in)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLevels(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	got, err := ParseJSON([]byte("[1,0,2,null,null,null,3]"))
+	if err != nil || !reflect.DeepEqual(got, testTree()) {
+		t.Errorf("ParseJSON = %v, %v, want %v", got, err, testTree())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		"1 - x",
+		"1 - 2.5",
+		"nil - 1",
+		"1 - nil - nil - 2",
+		"1,2",
+	} {
+		if got, err := ParseLevels(in); err == nil {
+			t.Errorf("ParseLevels(%q) = %v, want an error", in, got)
+		}
+	}
+	for _, in := range []string{`[1,"a"]`, `{}`, `[null,1]`, `[1,null,null,2]`} {
+		if got, err := ParseJSON([]byte(in)); err == nil {
+			t.Errorf("ParseJSON(%s) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for k := 1; k <= 20; k++ {
+		want := New(k)
+		got, err := ParseLevels(FormatLevels(want))
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseLevels(FormatLevels(%v)) = %v, %v", want, got, err)
+		}
+		data, err := FormatJSON(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = ParseJSON(data)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseJSON(%s) = %v, %v, want %v", data, got, err, want)
+		}
+	}
+}
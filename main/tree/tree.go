@@ -0,0 +1,66 @@
+// Package tree solves the Equivalent Binary Trees exercise
+// of the tour: walking a tree over a channel and comparing
+// two trees by their values.
+package tree
+
+import (
+	"context"
+
+	tour "golang.org/x/tour/tree"
+)
+
+// Tree is the binary tree of "golang.org/x/tour/tree".
+type Tree = tour.Tree
+
+// New returns a new, random binary tree holding the values k, 2k, ..., 10k.
+func New(k int) *Tree {
+	return tour.New(k)
+}
+
+// Walk sends the values of t to ch in order, then closes ch.
+//
+// Walk stops early, still closing ch, when ctx is done: the
+// receiver can cancel ctx instead of draining ch, so that the
+// goroutine running Walk does not leak.
+func Walk(ctx context.Context, t *Tree, ch chan<- int) {
+	defer close(ch)
+	walk(ctx, t, ch)
+}
+
+// walk reports whether the whole tree t was sent.
+func walk(ctx context.Context, t *Tree, ch chan<- int) bool {
+	if t == nil {
+		return true
+	}
+	if !walk(ctx, t.Left, ch) {
+		return false
+	}
+	select {
+	case ch <- t.Value:
+	case <-ctx.Done():
+		return false
+	}
+	return walk(ctx, t.Right, ch)
+}
+
+// Same reports whether t1 and t2 hold the same values in
+// the same order, whatever their shapes.
+func Same(t1, t2 *Tree) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	// Stops both walks when returning before their end.
+	defer cancel()
+
+	c1, c2 := make(chan int), make(chan int)
+	go Walk(ctx, t1, c1)
+	go Walk(ctx, t2, c2)
+	for {
+		v1, ok1 := <-c1
+		v2, ok2 := <-c2
+		if ok1 != ok2 || v1 != v2 {
+			return false
+		}
+		if !ok1 {
+			return true
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package tree
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// build returns a degenerate tree holding values, each node
+// being the right child of the previous one.
+func build(values ...int) *Tree {
+	var t *Tree
+	for i := len(values) - 1; i >= 0; i-- {
+		t = &Tree{Value: values[i], Right: t}
+	}
+	return t
+}
+
+func collect(t *Tree) []int {
+	ch := make(chan int)
+	go Walk(context.Background(), t, ch)
+	var values []int
+	for v := range ch {
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestWalk(t *testing.T) {
+	for k := 1; k <= 5; k++ {
+		values := collect(New(k))
+		if len(values) != 10 {
+			t.Fatalf("Walk(New(%d)) sent %d values, want 10", k, len(values))
+		}
+		for i, v := range values {
+			if want := (i + 1) * k; v != want {
+				t.Errorf("Walk(New(%d)) value %d = %d, want %d", k, i, v, want)
+			}
+		}
+	}
+	if values := collect(nil); len(values) != 0 {
+		t.Errorf("Walk(nil) sent %v, want nothing", values)
+	}
+}
+
+func TestWalkCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	go Walk(ctx, New(1), ch)
+	<-ch
+	cancel()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Walk did not close ch after ctx was canceled")
+		}
+	}
+}
+
+func TestSame(t *testing.T) {
+	tests := []struct {
+		name   string
+		t1, t2 *Tree
+		want   bool
+	}{
+		{"New(1), New(1)", New(1), New(1), true},
+		{"New(7), New(7)", New(7), New(7), true},
+		{"New(1), New(2)", New(1), New(2), false},
+		{"different shapes", New(3), build(3, 6, 9, 12, 15, 18, 21, 24, 27, 30), true},
+		{"shorter first", build(1, 2), build(1, 2, 3), false},
+		{"shorter second", build(1, 2, 3), build(1, 2), false},
+		{"nil, nil", nil, nil, true},
+		{"nil, New(1)", nil, New(1), false},
+	}
+	for _, tt := range tests {
+		if got := Same(tt.t1, tt.t2); got != tt.want {
+			t.Errorf("Same(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSameDoesNotLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for k := 1; k <= 50; k++ {
+		Same(New(k), New(k+1))
+	}
+	// The canceled walks need a moment to return.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("%d goroutines left running after Same returned", after-before)
+	}
+}